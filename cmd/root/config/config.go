@@ -0,0 +1,38 @@
+package config
+
+import (
+  "github.com/spf13/cobra"
+  cmdDecrypt "github.com/ushakovn/boiler/cmd/root/config/decrypt"
+  cmdDiff "github.com/ushakovn/boiler/cmd/root/config/diff"
+  cmdEncrypt "github.com/ushakovn/boiler/cmd/root/config/encrypt"
+  cmdKeygen "github.com/ushakovn/boiler/cmd/root/config/keygen"
+  cmdMigrate "github.com/ushakovn/boiler/cmd/root/config/migrate"
+  cmdPull "github.com/ushakovn/boiler/cmd/root/config/pull"
+  cmdPush "github.com/ushakovn/boiler/cmd/root/config/push"
+  cmdWatch "github.com/ushakovn/boiler/cmd/root/config/watch"
+)
+
+var CmdConfig = &cobra.Command{
+  Use: "config",
+
+  Short: "Manage a config of the microservice application",
+  Long:  `Manage a config of the microservice application`,
+
+  // Config commands not change go module
+  PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+    return nil
+  },
+}
+
+func init() {
+  CmdConfig.AddCommand(
+    cmdKeygen.CmdKeygen,
+    cmdEncrypt.CmdEncrypt,
+    cmdDecrypt.CmdDecrypt,
+    cmdDiff.CmdDiff,
+    cmdPush.CmdPush,
+    cmdPull.CmdPull,
+    cmdWatch.CmdWatch,
+    cmdMigrate.CmdMigrate,
+  )
+}
@@ -0,0 +1,60 @@
+package decrypt
+
+import (
+  "fmt"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/secret"
+)
+
+var (
+  flagConfigPath     string
+  flagBaseConfigPath string
+  flagName           string
+  flagValue          string
+)
+
+var CmdDecrypt = &cobra.Command{
+  Use: "decrypt",
+
+  Short: "Decrypt a secret values of the config",
+  Long: `Decrypt a secret values of the config.
+Encrypted values of the secret keys are decrypted in place for editing.
+Single value of the key is printed decrypted if value specified`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    key, err := secret.LoadKey()
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load key: %w", err)
+    }
+    if cmd.Flags().Changed("value") {
+      if flagName == "" {
+        return fmt.Errorf("boiler: name of the key must be specified with value")
+      }
+      decrypted, err := secret.Decrypt(key, flagName, flagValue)
+      if err != nil {
+        return fmt.Errorf("boiler: failed to decrypt value: %w", err)
+      }
+      fmt.Println(decrypted)
+
+      return nil
+    }
+    count, err := config.DecryptSecrets(flagConfigPath, flagBaseConfigPath, key)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to decrypt config: %w", err)
+    }
+    log.Infof("boiler: %d secret values decrypted in %s", count, flagConfigPath)
+    log.Warnf("boiler: encrypt config before commit")
+
+    return nil
+  },
+}
+
+func init() {
+  CmdDecrypt.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to decrypted config file")
+  CmdDecrypt.Flags().StringVar(&flagBaseConfigPath, "base-config-path", config.BaseConfigPath(), "path to base config file with key types")
+  CmdDecrypt.Flags().StringVar(&flagName, "name", "", "name of the key of decrypted value")
+  CmdDecrypt.Flags().StringVar(&flagValue, "value", "", "single value to decrypt")
+}
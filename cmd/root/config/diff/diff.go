@@ -0,0 +1,54 @@
+package diff
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/cmd/root/config/etcdflags"
+  "github.com/ushakovn/boiler/internal/pkg/configsync"
+  "github.com/ushakovn/boiler/pkg/config"
+)
+
+var (
+  flagConfigPath string
+  flagEtcd       etcdflags.Flags
+)
+
+var CmdDiff = &cobra.Command{
+  Use: "diff",
+
+  Short: "Compare a config values with etcd",
+  Long:  `Compare a config values with etcd under the app key prefix`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    layout, err := flagEtcd.KeyLayout()
+    if err != nil {
+      return fmt.Errorf("boiler: invalid etcd layout: %w", err)
+    }
+    syncer, err := configsync.Load(flagConfigPath, layout, flagEtcd.Options()...)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load config: %w", err)
+    }
+    defer func() { _ = syncer.Close() }()
+
+    changes, err := syncer.Diff(ctx)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to diff config: %w", err)
+    }
+    configsync.FormatChanges(os.Stdout, changes)
+
+    return nil
+  },
+}
+
+func init() {
+  CmdDiff.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to config file")
+
+  flagEtcd.Register(CmdDiff)
+}
@@ -0,0 +1,59 @@
+package encrypt
+
+import (
+  "fmt"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/secret"
+)
+
+var (
+  flagConfigPath     string
+  flagBaseConfigPath string
+  flagName           string
+  flagValue          string
+)
+
+var CmdEncrypt = &cobra.Command{
+  Use: "encrypt",
+
+  Short: "Encrypt a secret values of the config",
+  Long: `Encrypt a secret values of the config.
+Plaintext values of the secret keys are encrypted in place.
+Single value of the key is printed encrypted if value specified`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    key, err := secret.LoadKey()
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load key: %w", err)
+    }
+    if cmd.Flags().Changed("value") {
+      if flagName == "" {
+        return fmt.Errorf("boiler: name of the key must be specified with value")
+      }
+      encrypted, err := secret.Encrypt(key, flagName, flagValue)
+      if err != nil {
+        return fmt.Errorf("boiler: failed to encrypt value: %w", err)
+      }
+      fmt.Println(encrypted)
+
+      return nil
+    }
+    count, err := config.EncryptSecrets(flagConfigPath, flagBaseConfigPath, key)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to encrypt config: %w", err)
+    }
+    log.Infof("boiler: %d secret values encrypted in %s", count, flagConfigPath)
+
+    return nil
+  },
+}
+
+func init() {
+  CmdEncrypt.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to encrypted config file")
+  CmdEncrypt.Flags().StringVar(&flagBaseConfigPath, "base-config-path", config.BaseConfigPath(), "path to base config file with key types")
+  CmdEncrypt.Flags().StringVar(&flagName, "name", "", "name of the key of encrypted value")
+  CmdEncrypt.Flags().StringVar(&flagValue, "value", "", "single value to encrypt")
+}
@@ -0,0 +1,36 @@
+package etcdflags
+
+import (
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/pkg/config/provider/etcd"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+// Flags etcd connection and key layout flags shared by config commands,
+// credentials and TLS files are taken from env
+type Flags struct {
+  Endpoints  []string
+  Layout     string
+  LayoutRoot string
+  AppEnv     string
+}
+
+func (f *Flags) Register(cmd *cobra.Command) {
+  settings := env.Boiler()
+
+  cmd.Flags().StringSliceVar(&f.Endpoints, "etcd-endpoints", nil, "etcd endpoints instead of env endpoints")
+  cmd.Flags().StringVar(&f.Layout, "etcd-layout", settings.Etcd.Layout, "etcd key layout: flat or hierarchical")
+  cmd.Flags().StringVar(&f.LayoutRoot, "etcd-layout-root", settings.Etcd.LayoutRoot, "root of the hierarchical etcd key layout")
+  cmd.Flags().StringVar(&f.AppEnv, "env", settings.AppEnv.String(), "app env of the hierarchical etcd key layout")
+}
+
+func (f *Flags) Options() []etcd.Option {
+  if len(f.Endpoints) == 0 {
+    return nil
+  }
+  return []etcd.Option{etcd.WithEndpoints(f.Endpoints...)}
+}
+
+func (f *Flags) KeyLayout() (etcd.Layout, error) {
+  return etcd.ParseLayout(f.Layout, f.LayoutRoot, env.Env(f.AppEnv))
+}
@@ -0,0 +1,44 @@
+package keygen
+
+import (
+  "fmt"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/internal/pkg/filer"
+  "github.com/ushakovn/boiler/pkg/config/secret"
+)
+
+var (
+  flagKeyPath string
+  flagForce   bool
+)
+
+var CmdKeygen = &cobra.Command{
+  Use: "keygen",
+
+  Short: "Generate a key file for the config secrets",
+  Long:  `Generate a key file for the config secrets`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    if filer.IsExistedFile(flagKeyPath) && !flagForce {
+      return fmt.Errorf("boiler: key file already exists: %s", flagKeyPath)
+    }
+    key, err := secret.GenerateKey()
+    if err != nil {
+      return fmt.Errorf("boiler: failed to generate key: %w", err)
+    }
+    if err = secret.WriteKey(flagKeyPath, key); err != nil {
+      return fmt.Errorf("boiler: failed to write key: %w", err)
+    }
+    log.Infof("boiler: key file generated: %s", flagKeyPath)
+    log.Warnf("boiler: key file must not be committed, add it to .gitignore")
+
+    return nil
+  },
+}
+
+func init() {
+  CmdKeygen.Flags().StringVar(&flagKeyPath, "key-path", secret.KeyPath(), "path to generated key file")
+  CmdKeygen.Flags().BoolVar(&flagForce, "force", false, "overwrite existed key file")
+}
@@ -0,0 +1,85 @@
+package migrate
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/cmd/root/config/etcdflags"
+  "github.com/ushakovn/boiler/internal/pkg/configsync"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/provider/etcd"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+var (
+  flagConfigPath     string
+  flagEtcd           etcdflags.Flags
+  flagFromLayout     string
+  flagFromLayoutRoot string
+  flagNamespaces     []string
+  flagDeleteOld      bool
+  flagDryRun         bool
+)
+
+var CmdMigrate = &cobra.Command{
+  Use: "migrate",
+
+  Short: "Migrate a config values in etcd to another key layout",
+  Long: `Migrate a config values in etcd to another key layout.
+Only keys of the config are moved for the app,
+all keys are moved for the specified shared namespaces.
+New keys with another values are not overwritten.
+Moves are written in etcd transactions of at most 128 ops,
+migration of more keys is not atomic`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    from, err := etcd.ParseLayout(flagFromLayout, flagFromLayoutRoot, env.Env(flagEtcd.AppEnv))
+    if err != nil {
+      return fmt.Errorf("boiler: invalid source etcd layout: %w", err)
+    }
+    to, err := flagEtcd.KeyLayout()
+    if err != nil {
+      return fmt.Errorf("boiler: invalid etcd layout: %w", err)
+    }
+    syncer, err := configsync.Load(flagConfigPath, to, flagEtcd.Options()...)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load config: %w", err)
+    }
+    defer func() { _ = syncer.Close() }()
+
+    moves, err := syncer.PlanMigration(ctx, from, flagNamespaces...)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to plan migration: %w", err)
+    }
+    configsync.FormatMoves(os.Stdout, moves)
+
+    if flagDryRun {
+      log.Infof("boiler: dry run, etcd not changed")
+      return nil
+    }
+    if err = syncer.Migrate(ctx, moves, flagDeleteOld); err != nil {
+      return fmt.Errorf("boiler: failed to migrate: %w", err)
+    }
+    log.Infof("boiler: etcd keys migrated")
+
+    return nil
+  },
+}
+
+func init() {
+  CmdMigrate.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to config file")
+  CmdMigrate.Flags().StringVar(&flagFromLayout, "from-layout", etcd.FlatLayoutName, "source etcd key layout: flat or hierarchical")
+  CmdMigrate.Flags().StringVar(&flagFromLayoutRoot, "from-layout-root", env.Boiler().Etcd.LayoutRoot, "root of the source hierarchical etcd key layout")
+  CmdMigrate.Flags().StringSliceVar(&flagNamespaces, "namespaces", nil, "shared namespaces to migrate")
+  CmdMigrate.Flags().BoolVar(&flagDeleteOld, "delete-old", false, "delete migrated keys of the source layout")
+  CmdMigrate.Flags().BoolVar(&flagDryRun, "dry-run", false, "print moves without writing")
+
+  flagEtcd.Register(CmdMigrate)
+}
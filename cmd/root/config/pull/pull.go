@@ -0,0 +1,69 @@
+package pull
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/cmd/root/config/etcdflags"
+  "github.com/ushakovn/boiler/internal/pkg/configsync"
+  "github.com/ushakovn/boiler/pkg/config"
+)
+
+var (
+  flagConfigPath string
+  flagEtcd       etcdflags.Flags
+  flagDryRun     bool
+)
+
+var CmdPull = &cobra.Command{
+  Use: "pull",
+
+  Short: "Pull a config values from etcd",
+  Long: `Pull a config values from etcd under the app key prefix.
+Values of the known keys are validated by key types and rules before writing.
+Secret keys are never pulled`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    layout, err := flagEtcd.KeyLayout()
+    if err != nil {
+      return fmt.Errorf("boiler: invalid etcd layout: %w", err)
+    }
+    syncer, err := configsync.Load(flagConfigPath, layout, flagEtcd.Options()...)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load config: %w", err)
+    }
+    defer func() { _ = syncer.Close() }()
+
+    changes, err := syncer.Diff(ctx)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to diff config: %w", err)
+    }
+    configsync.FormatChanges(os.Stdout, changes)
+
+    if flagDryRun {
+      log.Infof("boiler: dry run, config file not changed")
+      return nil
+    }
+    count, err := syncer.Pull(flagConfigPath, changes)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to pull config: %w", err)
+    }
+    log.Infof("boiler: %d values pulled from etcd into %s", count, flagConfigPath)
+
+    return nil
+  },
+}
+
+func init() {
+  CmdPull.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to config file")
+  CmdPull.Flags().BoolVar(&flagDryRun, "dry-run", false, "print changes without writing")
+
+  flagEtcd.Register(CmdPull)
+}
@@ -0,0 +1,76 @@
+package push
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "time"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/cmd/root/config/etcdflags"
+  "github.com/ushakovn/boiler/internal/pkg/configsync"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/provider/etcd"
+)
+
+var (
+  flagConfigPath string
+  flagEtcd       etcdflags.Flags
+  flagDryRun     bool
+  flagPrune      bool
+)
+
+var CmdPush = &cobra.Command{
+  Use: "push",
+
+  Short: "Push a config values to etcd",
+  Long: `Push a config values to etcd under the app key prefix.
+Values are validated by key types and rules before writing.
+Changes are written in etcd transactions of at most 128 ops,
+push of more changes is not atomic.
+Secret keys are never pushed`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    layout, err := flagEtcd.KeyLayout()
+    if err != nil {
+      return fmt.Errorf("boiler: invalid etcd layout: %w", err)
+    }
+    if flagPrune && etcd.IsFlatLayout(layout) {
+      return fmt.Errorf("boiler: prune is not supported by flat etcd layout: app prefix is shared with apps of the same name prefix")
+    }
+    syncer, err := configsync.Load(flagConfigPath, layout, flagEtcd.Options()...)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load config: %w", err)
+    }
+    defer func() { _ = syncer.Close() }()
+
+    changes, err := syncer.Diff(ctx)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to diff config: %w", err)
+    }
+    configsync.FormatChanges(os.Stdout, changes)
+
+    if flagDryRun {
+      log.Infof("boiler: dry run, etcd not changed")
+      return nil
+    }
+    if err = syncer.Push(ctx, changes, flagPrune); err != nil {
+      return fmt.Errorf("boiler: failed to push config: %w", err)
+    }
+    log.Infof("boiler: config pushed to etcd")
+
+    return nil
+  },
+}
+
+func init() {
+  CmdPush.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to config file")
+  CmdPush.Flags().BoolVar(&flagDryRun, "dry-run", false, "print changes without writing")
+  CmdPush.Flags().BoolVar(&flagPrune, "prune", false, "delete etcd keys under the app prefix missing in config, not supported by flat layout")
+
+  flagEtcd.Register(CmdPush)
+}
@@ -0,0 +1,65 @@
+package watch
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "os/signal"
+  "syscall"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/spf13/cobra"
+  "github.com/ushakovn/boiler/cmd/root/config/etcdflags"
+  "github.com/ushakovn/boiler/internal/pkg/configsync"
+  "github.com/ushakovn/boiler/pkg/config"
+)
+
+var (
+  flagConfigPath string
+  flagEtcd       etcdflags.Flags
+)
+
+var CmdWatch = &cobra.Command{
+  Use: "watch",
+
+  Short: "Watch a config values changes in etcd",
+  Long:  `Watch a config values changes in etcd under the app key prefix`,
+
+  RunE: func(cmd *cobra.Command, args []string) error {
+    ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer cancel()
+
+    layout, err := flagEtcd.KeyLayout()
+    if err != nil {
+      return fmt.Errorf("boiler: invalid etcd layout: %w", err)
+    }
+    syncer, err := configsync.Load(flagConfigPath, layout, flagEtcd.Options()...)
+    if err != nil {
+      return fmt.Errorf("boiler: failed to load config: %w", err)
+    }
+    defer func() { _ = syncer.Close() }()
+
+    log.Infof("boiler: watching config changes in etcd")
+
+    err = syncer.Watch(ctx, func(event *configsync.Event) {
+      switch {
+      case event.Deleted:
+        fmt.Printf("- %s deleted\n", event.EtcdKey)
+      case event.Err != nil:
+        fmt.Printf("! %s = %q invalid: %v\n", event.EtcdKey, event.Value, event.Err)
+      default:
+        fmt.Printf("~ %s = %q\n", event.EtcdKey, event.Value)
+      }
+    })
+    if err != nil {
+      return fmt.Errorf("boiler: failed to watch config: %w", err)
+    }
+    return nil
+  },
+}
+
+func init() {
+  CmdWatch.Flags().StringVar(&flagConfigPath, "config-path", config.BaseConfigPath(), "path to config file")
+
+  flagEtcd.Register(CmdWatch)
+}
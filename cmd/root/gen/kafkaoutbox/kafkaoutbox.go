@@ -42,7 +42,7 @@ var CmdKafkaoutbox = &cobra.Command{
 
 func init() {
   CmdKafkaoutbox.Flags().BoolVar(&flagValidateProto, "validate-proto", false, "validate kafkaoutbox proto with pg schema")
-  CmdKafkaoutbox.Flags().StringVar(&flagStorageConfigPath, "storage-config-path", "", "path to storage generator config")
+  CmdKafkaoutbox.Flags().StringVar(&flagStorageConfigPath, "storage-config-path", "", "path to storage generator config with primary keys of source tables")
 
-  CmdKafkaoutbox.MarkFlagsRequiredTogether("validate-proto", "storage-config-path")
+  _ = CmdKafkaoutbox.MarkFlagRequired("storage-config-path")
 }
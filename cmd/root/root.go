@@ -7,6 +7,7 @@ import (
 
   log "github.com/sirupsen/logrus"
   "github.com/spf13/cobra"
+  cmdConfig "github.com/ushakovn/boiler/cmd/root/config"
   cmdGen "github.com/ushakovn/boiler/cmd/root/gen"
   cmdInit "github.com/ushakovn/boiler/cmd/root/init"
   "github.com/ushakovn/boiler/internal/pkg/executor"
@@ -44,7 +45,7 @@ func Execute() {
 }
 
 func init() {
-  CmdRoot.AddCommand(cmdInit.CmdInit, cmdGen.CmdGen)
+  CmdRoot.AddCommand(cmdInit.CmdInit, cmdGen.CmdGen, cmdConfig.CmdConfig)
 
   CmdRoot.PersistentFlags().BoolVar(&flagDebug, "enable-debug", false, "sets debug logging level")
 }
@@ -66,7 +66,8 @@ CREATE TABLE public.product (
     short_description text,
     full_description text,
     cost_in_cents integer NOT NULL,
-    attrs jsonb
+    attrs jsonb,
+    updated_at timestamp(3) with time zone
 );
 
 
@@ -0,0 +1,328 @@
+package configsync
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "io"
+  "sort"
+  "strings"
+
+  "github.com/ushakovn/boiler/internal/pkg/builder"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/provider/etcd"
+  "github.com/ushakovn/boiler/pkg/config/types"
+  v3 "go.etcd.io/etcd/client/v3"
+)
+
+const secretValueType = "secret"
+
+type Kind string
+
+const (
+  // Key exists only in the config file
+  KindAdded Kind = "added"
+  // Values of the config file and etcd differ
+  KindChanged Kind = "changed"
+  // Key exists only in etcd under the app prefix, not reported
+  // for the flat layout sharing prefix with the same name prefix apps
+  KindRemoved Kind = "removed"
+)
+
+// Change difference between the config file and etcd
+type Change struct {
+  Key     string
+  EtcdKey string
+  Kind    Kind
+  Local   string
+  Remote  string
+}
+
+// Event change of the app key in etcd
+type Event struct {
+  Key     string
+  EtcdKey string
+  Value   string
+  Deleted bool
+  // Value not valid for the key spec
+  Err error
+}
+
+// Syncer syncs values of the custom section with etcd under the app prefix,
+// secret keys are never synced
+type Syncer struct {
+  appName string
+  section config.CustomSection
+  layout  etcd.Layout
+  client  *v3.Client
+}
+
+func New(parsed *config.Parsed, layout etcd.Layout, client *v3.Client) *Syncer {
+  return &Syncer{
+    appName: parsed.App.Name,
+    section: parsed.Custom,
+    layout:  layout,
+    client:  client,
+  }
+}
+
+// Load parses and validates the config file and connects to etcd
+func Load(configPath string, layout etcd.Layout, calls ...etcd.Option) (*Syncer, error) {
+  parsed, err := config.ParseConfig(configPath)
+  if err != nil {
+    return nil, fmt.Errorf("config parsing failed: %w", err)
+  }
+  if err = parsed.Validate(); err != nil {
+    return nil, fmt.Errorf("config validation failed:\n%v", err)
+  }
+  client, err := etcd.NewClient(calls...)
+  if err != nil {
+    return nil, fmt.Errorf("etcd client creation failed: %w", err)
+  }
+  return New(parsed, layout, client), nil
+}
+
+func (s *Syncer) Close() error {
+  return s.client.Close()
+}
+
+// Diff compares values of the config file with etcd
+func (s *Syncer) Diff(ctx context.Context) ([]*Change, error) {
+  remote, err := s.remoteValues(ctx)
+  if err != nil {
+    return nil, err
+  }
+  var changes []*Change
+
+  for key, etcdKey := range s.etcdKeys() {
+    local := s.section[config.CustomSectionKey(key)].Value
+
+    remoteValue, ok := remote[etcdKey]
+    delete(remote, etcdKey)
+
+    switch {
+    case !ok:
+      changes = append(changes, &Change{
+        Key:     key,
+        EtcdKey: etcdKey,
+        Kind:    KindAdded,
+        Local:   local,
+      })
+    case remoteValue != local:
+      changes = append(changes, &Change{
+        Key:     key,
+        EtcdKey: etcdKey,
+        Kind:    KindChanged,
+        Local:   local,
+        Remote:  remoteValue,
+      })
+    }
+  }
+  // Keys of the app foo_bar are under the flat prefix of the app foo
+  if !s.ownsPrefix() {
+    remote = nil
+  }
+  prefix := s.layout.Prefix(s.appName)
+
+  for etcdKey, remoteValue := range remote {
+    key := strings.TrimPrefix(etcdKey, prefix)
+
+    // Secret keys are not synced
+    if val, ok := s.section[config.CustomSectionKey(key)]; ok && val.Type == secretValueType {
+      continue
+    }
+    changes = append(changes, &Change{
+      Key:     key,
+      EtcdKey: etcdKey,
+      Kind:    KindRemoved,
+      Remote:  remoteValue,
+    })
+  }
+  sort.Slice(changes, func(i, j int) bool {
+    return changes[i].EtcdKey < changes[j].EtcdKey
+  })
+  return changes, nil
+}
+
+// Push writes values of the config file into etcd in transactions of at most maxTxnOps ops,
+// push of more changes is not atomic, keys existing only in etcd are deleted when prune specified
+func (s *Syncer) Push(ctx context.Context, changes []*Change, prune bool) error {
+  if err := s.validate(changes, func(change *Change) (string, bool) {
+    return change.Local, change.Kind != KindRemoved
+  }); err != nil {
+    return err
+  }
+  ops := make([]v3.Op, 0, len(changes))
+
+  for _, change := range changes {
+    switch change.Kind {
+    case KindAdded, KindChanged:
+      ops = append(ops, v3.OpPut(change.EtcdKey, change.Local))
+    case KindRemoved:
+      if prune {
+        ops = append(ops, v3.OpDelete(change.EtcdKey))
+      }
+    }
+  }
+  return s.commit(ctx, ops)
+}
+
+// maxTxnOps default limit of the ops in the etcd transaction set by --max-txn-ops
+const maxTxnOps = 128
+
+// commit applies ops in order in transactions of at most maxTxnOps ops,
+// ops of the failed transaction and the next ones are not applied
+func (s *Syncer) commit(ctx context.Context, ops []v3.Op) error {
+  for applied := 0; applied < len(ops); applied += maxTxnOps {
+    batch := ops[applied:]
+    if len(batch) > maxTxnOps {
+      batch = batch[:maxTxnOps]
+    }
+    if _, err := s.client.Txn(ctx).Then(batch...).Commit(); err != nil {
+      return fmt.Errorf("etcd txn failed: %d of %d ops applied: %w", applied, len(ops), err)
+    }
+  }
+  return nil
+}
+
+// Pull writes values of etcd into the config file
+func (s *Syncer) Pull(path string, changes []*Change) (int, error) {
+  if err := s.validate(changes, func(change *Change) (string, bool) {
+    return change.Remote, change.Kind == KindChanged
+  }); err != nil {
+    return 0, err
+  }
+  values := map[string]string{}
+
+  for _, change := range changes {
+    if change.Kind == KindChanged {
+      values[change.Key] = change.Remote
+    }
+  }
+  return config.UpdateValues(path, values)
+}
+
+// Watch calls action on changes of the app keys in etcd until ctx done
+func (s *Syncer) Watch(ctx context.Context, action func(event *Event)) error {
+  keys := map[string]string{}
+
+  for key, etcdKey := range s.etcdKeys() {
+    keys[etcdKey] = key
+  }
+  prefix := s.layout.Prefix(s.appName)
+
+  for resp := range s.client.Watch(ctx, prefix, v3.WithPrefix()) {
+    if err := resp.Err(); err != nil {
+      return fmt.Errorf("etcd watch failed: %w", err)
+    }
+    for _, event := range resp.Events {
+      if event.Kv == nil {
+        continue
+      }
+      etcdKey := string(event.Kv.Key)
+
+      key, ok := keys[etcdKey]
+      if !ok && !s.ownsPrefix() {
+        continue
+      }
+      if !ok {
+        key = strings.TrimPrefix(etcdKey, prefix)
+      }
+      e := &Event{
+        Key:     key,
+        EtcdKey: etcdKey,
+        Value:   string(event.Kv.Value),
+        Deleted: event.Type == v3.EventTypeDelete,
+      }
+      if val, ok := s.section[config.CustomSectionKey(key)]; ok && !e.Deleted {
+        _, e.Err = types.ParseValue(specOf(val), e.Value)
+      }
+      action(e)
+    }
+  }
+  return nil
+}
+
+// validate checks values of the changes with specs of the keys
+func (s *Syncer) validate(changes []*Change, value func(change *Change) (string, bool)) error {
+  b := builder.NewBuilder()
+
+  for _, change := range changes {
+    rawValue, ok := value(change)
+    if !ok {
+      continue
+    }
+    val, ok := s.section[config.CustomSectionKey(change.Key)]
+    if !ok {
+      continue
+    }
+    if _, err := types.ParseValue(specOf(val), rawValue); err != nil {
+      b.Write("\tkey %s: %v\n", change.Key, err)
+    }
+  }
+  if msg := b.String(); msg != "" {
+    return errors.New("invalid values:\n" + msg)
+  }
+  return nil
+}
+
+// remoteValues returns values under the app prefix by etcd keys
+func (s *Syncer) remoteValues(ctx context.Context) (map[string]string, error) {
+  return s.prefixValues(ctx, s.layout.Prefix(s.appName))
+}
+
+func (s *Syncer) prefixValues(ctx context.Context, prefix string) (map[string]string, error) {
+  resp, err := s.client.Get(ctx, prefix, v3.WithPrefix())
+  if err != nil {
+    return nil, fmt.Errorf("etcd get failed: %w", err)
+  }
+  values := make(map[string]string, len(resp.Kvs))
+
+  for _, kv := range resp.Kvs {
+    values[string(kv.Key)] = string(kv.Value)
+  }
+  return values, nil
+}
+
+// ownsPrefix reports if all keys under the app prefix belong to the app
+func (s *Syncer) ownsPrefix() bool {
+  return !etcd.IsFlatLayout(s.layout)
+}
+
+// etcdKeys returns etcd keys by synced keys of the section
+func (s *Syncer) etcdKeys() map[string]string {
+  keys := make(map[string]string, len(s.section))
+
+  for key, val := range s.section {
+    if val.Type == secretValueType {
+      continue
+    }
+    keys[key.String()] = s.layout.Key(s.appName, key.String())
+  }
+  return keys
+}
+
+func specOf(val *config.CustomSectionVal) *types.Spec {
+  return &types.Spec{
+    Type:  val.Type,
+    Rules: val.Rules,
+  }
+}
+
+// FormatChanges writes changes in human readable form
+func FormatChanges(w io.Writer, changes []*Change) {
+  if len(changes) == 0 {
+    _, _ = fmt.Fprintln(w, "no changes")
+    return
+  }
+  for _, change := range changes {
+    switch change.Kind {
+    case KindAdded:
+      _, _ = fmt.Fprintf(w, "+ %s = %q (only in file)\n", change.EtcdKey, change.Local)
+    case KindChanged:
+      _, _ = fmt.Fprintf(w, "~ %s: %q (etcd) != %q (file)\n", change.EtcdKey, change.Remote, change.Local)
+    case KindRemoved:
+      _, _ = fmt.Fprintf(w, "- %s = %q (only in etcd)\n", change.EtcdKey, change.Remote)
+    }
+  }
+}
@@ -0,0 +1,120 @@
+package configsync
+
+import (
+  "context"
+  "fmt"
+  "io"
+  "sort"
+  "strings"
+
+  "github.com/ushakovn/boiler/pkg/config/provider/etcd"
+  v3 "go.etcd.io/etcd/client/v3"
+)
+
+// Move of the etcd value from the old layout key to the new one
+type Move struct {
+  From  string
+  To    string
+  Value string
+  // Value of the new key differs and is not overwritten
+  Conflict bool
+  // New key already contains the same value
+  Done bool
+}
+
+// PlanMigration plans moves of the values from the old layout to the syncer layout:
+// only keys of the custom section are moved for the app to avoid
+// taking keys of the apps with the same name prefix,
+// all keys under the prefix are moved for the namespaces
+func (s *Syncer) PlanMigration(ctx context.Context, from etcd.Layout, namespaces ...string) ([]*Move, error) {
+  var moves []*Move
+
+  appValues, err := s.prefixValues(ctx, from.Prefix(s.appName))
+  if err != nil {
+    return nil, err
+  }
+  for key := range s.section {
+    fromKey := from.Key(s.appName, key.String())
+
+    value, ok := appValues[fromKey]
+    if !ok {
+      continue
+    }
+    moves = append(moves, &Move{
+      From:  fromKey,
+      To:    s.layout.Key(s.appName, key.String()),
+      Value: value,
+    })
+  }
+  for _, namespace := range namespaces {
+    prefix := from.Prefix(namespace)
+
+    values, err := s.prefixValues(ctx, prefix)
+    if err != nil {
+      return nil, err
+    }
+    for fromKey, value := range values {
+      moves = append(moves, &Move{
+        From:  fromKey,
+        To:    s.layout.Key(namespace, strings.TrimPrefix(fromKey, prefix)),
+        Value: value,
+      })
+    }
+  }
+  for _, move := range moves {
+    resp, err := s.client.Get(ctx, move.To)
+    if err != nil {
+      return nil, fmt.Errorf("etcd get failed: %w", err)
+    }
+    if len(resp.Kvs) == 0 {
+      continue
+    }
+    if string(resp.Kvs[0].Value) == move.Value {
+      move.Done = true
+    } else {
+      move.Conflict = true
+    }
+  }
+  sort.Slice(moves, func(i, j int) bool {
+    return moves[i].From < moves[j].From
+  })
+  return moves, nil
+}
+
+// Migrate applies planned moves in transactions of at most maxTxnOps ops,
+// migration of more moves is not atomic but new keys are put before old keys deleted,
+// conflicts are skipped, old keys of the applied moves are deleted when specified
+func (s *Syncer) Migrate(ctx context.Context, moves []*Move, deleteOld bool) error {
+  var ops []v3.Op
+
+  for _, move := range moves {
+    if move.Conflict || move.From == move.To {
+      continue
+    }
+    if !move.Done {
+      ops = append(ops, v3.OpPut(move.To, move.Value))
+    }
+    if deleteOld {
+      ops = append(ops, v3.OpDelete(move.From))
+    }
+  }
+  return s.commit(ctx, ops)
+}
+
+// FormatMoves writes moves in human readable form
+func FormatMoves(w io.Writer, moves []*Move) {
+  if len(moves) == 0 {
+    _, _ = fmt.Fprintln(w, "no keys to migrate")
+    return
+  }
+  for _, move := range moves {
+    switch {
+    case move.Conflict:
+      _, _ = fmt.Fprintf(w, "! %s -> %s (conflict: new key has another value)\n", move.From, move.To)
+    case move.Done:
+      _, _ = fmt.Fprintf(w, "= %s -> %s (already migrated)\n", move.From, move.To)
+    default:
+      _, _ = fmt.Fprintf(w, "> %s -> %s\n", move.From, move.To)
+    }
+  }
+}
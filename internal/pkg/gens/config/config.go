@@ -2,8 +2,12 @@ package config
 
 import (
   "context"
+  "encoding/json"
+  "errors"
   "fmt"
+  "os"
   "path/filepath"
+  "strings"
   "text/template"
 
   "github.com/ushakovn/boiler/internal/pkg/filer"
@@ -57,20 +61,60 @@ func (g *GenConfig) Generate(_ context.Context) error {
     "toUpperCamelCase": stringer.StringToUpperCamelCase,
     "toSnakeCase":      stringer.StringToSnakeCase,
     "toCapitalizeCase": stringer.StringToCapitalizeCase,
+    "toMarkdownCell":   toMarkdownCell,
   }
   for _, cnf := range configTemplates {
     filePath := filepath.Join(configFolder, cnf.fileName)
 
+    // Previously generated file may refer to removed keys
+    if cnf.skipGenerate != nil && cnf.skipGenerate(configDesc) {
+      if err = os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+        return fmt.Errorf("os.Remove: %w", err)
+      }
+      continue
+    }
     if err = templater.ExecTemplateCopyWithGoFmt(cnf.compiledTemplate, filePath, configDesc, templatesFuncMap); err != nil {
       return fmt.Errorf("execTemplateCopy: %w", err)
     }
   }
+  if err = g.generateDocs(configDesc, templatesFuncMap); err != nil {
+    return err
+  }
+  return nil
+}
+
+// generateDocs generates JSON Schema and Markdown reference of the config file
+func (g *GenConfig) generateDocs(configDesc *genConfigDesc, templatesFuncMap template.FuncMap) error {
+  configFolder := filepath.Join(g.workDirPath, ".config")
+
+  schemaBuf, err := json.MarshalIndent(configDesc.schema, "", "  ")
+  if err != nil {
+    return fmt.Errorf("json.MarshalIndent: %w", err)
+  }
+  schemaPath := filepath.Join(configFolder, "app_config.schema.json")
+
+  if err = os.WriteFile(schemaPath, append(schemaBuf, '\n'), os.ModePerm); err != nil {
+    return fmt.Errorf("os.WriteFile: %w", err)
+  }
+  docsPath := filepath.Join(configFolder, "app_config.md")
+
+  if err = templater.ExecTemplateCopy(templates.GenConfigDocs, docsPath, configDesc, templatesFuncMap); err != nil {
+    return fmt.Errorf("execTemplateCopy: %w", err)
+  }
   return nil
 }
 
+// toMarkdownCell escapes text for Markdown table cell
+func toMarkdownCell(s string) string {
+  s = strings.ReplaceAll(s, "|", "\\|")
+  s = strings.ReplaceAll(s, "\n", " ")
+  return s
+}
+
 type configTemplate struct {
   fileName         string
   compiledTemplate string
+  skipGenerate     func(desc *genConfigDesc) bool
 }
 
 var configTemplates = []*configTemplate{
@@ -87,4 +131,15 @@ var configTemplates = []*configTemplate{
     fileName:         "provider.go",
     compiledTemplate: templates.GenConfigProvider,
   },
+  {
+    fileName:         "accessors.go",
+    compiledTemplate: templates.GenConfigAccessors,
+  },
+  {
+    fileName:         "flags.go",
+    compiledTemplate: templates.GenConfigFlags,
+    skipGenerate: func(desc *genConfigDesc) bool {
+      return len(desc.FlagKeys) == 0
+    },
+  },
 }
@@ -3,18 +3,32 @@ package config
 import (
   "fmt"
   "path/filepath"
+  "regexp"
+  "sort"
   "strings"
 
+  "github.com/ushakovn/boiler/internal/pkg/validator"
   "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/types"
+  "github.com/ushakovn/boiler/pkg/flags"
   "golang.org/x/text/cases"
   "golang.org/x/text/language"
 )
 
 type genConfigDesc struct {
-  ConfigGroups     []*groupDesc
-  ConfigPackages   []*goPackageDesc
-  GroupsPackages   []*goPackageDesc
-  ProviderPackages []*goPackageDesc
+  AppName           string
+  AppDescription    string
+  ConfigGroups      []*groupDesc
+  FlagKeys          []*groupKeyDesc
+  AccessorKeys      []*groupKeyDesc
+  ConfigPackages    []*goPackageDesc
+  GroupsPackages    []*goPackageDesc
+  ProviderPackages  []*goPackageDesc
+  FlagsPackages     []*goPackageDesc
+  AccessorsPackages []*goPackageDesc
+
+  // JSON Schema of the config file
+  schema *config.JSONSchema
 }
 
 type groupDesc struct {
@@ -23,11 +37,21 @@ type groupDesc struct {
 }
 
 type groupKeyDesc struct {
-  KeyName     string
-  KeyNameTrim string
-  KeyComment  string
-  ValueType   string
-  ValueCall   string
+  KeyName      string
+  KeyNameTrim  string
+  KeyComment   string
+  Type         string
+  ValueType    string
+  ValueCall    string
+  DefaultValue string
+  RulesDesc    string
+  HasDefault   bool
+  IsFlag       bool
+  IsJSON       bool
+  // Go type of the json value qualified with package alias
+  GoType string
+
+  goTypePackage *goPackageDesc
 }
 
 type goPackageDesc struct {
@@ -48,19 +72,166 @@ func (g *GenConfig) loadGenConfigDesc() (*genConfigDesc, error) {
   if err = parsed.Validate(); err != nil {
     return nil, fmt.Errorf("config validation failed:\n%v", err)
   }
-  genConfig := buildGenConfig(parsed.Custom)
+  if err = validateFlags(parsed.Custom); err != nil {
+    return nil, fmt.Errorf("config flags validation failed:\n%v", err)
+  }
+  genConfig := buildGenConfig(parsed)
 
+  if err = validateGoTypes(genConfig.AccessorKeys); err != nil {
+    return nil, fmt.Errorf("config go types validation failed:\n%v", err)
+  }
   return genConfig, nil
 }
 
-func buildGenConfig(customSection config.CustomSection) *genConfigDesc {
-  configGroups := buildConfigGroups(customSection)
+func buildGenConfig(parsed *config.Parsed) *genConfigDesc {
+  configGroups := buildConfigGroups(parsed.Custom)
+  flagKeys := buildFlagKeys(configGroups)
+  accessorKeys := buildAccessorKeys(configGroups)
 
   return &genConfigDesc{
-    ConfigGroups:     configGroups,
-    ConfigPackages:   buildPackagesByNames(configPackages...),
-    GroupsPackages:   buildPackagesByNames(groupsPackages...),
-    ProviderPackages: buildPackagesByNames(providerPackages...),
+    AppName:           parsed.App.Name,
+    AppDescription:    parsed.App.Description,
+    ConfigGroups:      configGroups,
+    FlagKeys:          flagKeys,
+    AccessorKeys:      accessorKeys,
+    ConfigPackages:    buildPackagesByNames(configPackages...),
+    GroupsPackages:    buildPackagesByNames(groupsPackages...),
+    ProviderPackages:  buildPackagesByNames(providerPackages...),
+    FlagsPackages:     buildPackagesByNames(flagsPackages...),
+    AccessorsPackages: buildAccessorsPackages(accessorKeys),
+    schema:            parsed.Custom.JSONSchema(parsed.App),
+  }
+}
+
+func validateFlags(customSection config.CustomSection) error {
+  fs := make([]validator.ValidateFunc, 0, len(customSection))
+
+  for key, val := range customSection {
+    if val.Type != flagValueType {
+      continue
+    }
+    keyName := key.String()
+    rawSpec := val.Value
+
+    fs = append(fs, func() error {
+      if _, err := flags.ParseSpec(rawSpec); err != nil {
+        return fmt.Errorf("flag: %s invalid:\n%v", keyName, err)
+      }
+      return nil
+    })
+  }
+  return validator.Validate(fs...)
+}
+
+func buildFlagKeys(configGroups []*groupDesc) []*groupKeyDesc {
+  var flagKeys []*groupKeyDesc
+
+  for _, group := range configGroups {
+    for _, key := range group.GroupKeys {
+      if key.IsFlag {
+        flagKeys = append(flagKeys, key)
+      }
+    }
+  }
+  sort.Slice(flagKeys, func(i, j int) bool {
+    return flagKeys[i].KeyName < flagKeys[j].KeyName
+  })
+  return flagKeys
+}
+
+// buildAccessorKeys returns sorted keys with typed accessors, flags have own accessors
+func buildAccessorKeys(configGroups []*groupDesc) []*groupKeyDesc {
+  var accessorKeys []*groupKeyDesc
+
+  for _, group := range configGroups {
+    for _, key := range group.GroupKeys {
+      if !key.IsFlag {
+        accessorKeys = append(accessorKeys, key)
+      }
+    }
+  }
+  sort.Slice(accessorKeys, func(i, j int) bool {
+    return accessorKeys[i].KeyName < accessorKeys[j].KeyName
+  })
+  return accessorKeys
+}
+
+func buildAccessorsPackages(accessorKeys []*groupKeyDesc) []*goPackageDesc {
+  names := []string{
+    contextPackageName,
+    typesPackageName,
+  }
+  var (
+    withTime, withJSON bool
+    goTypesPackages    []*goPackageDesc
+  )
+  for _, key := range accessorKeys {
+    withTime = withTime || strings.HasPrefix(key.ValueType, "time.")
+    // Json values with go type are unmarshalled by config value
+    withJSON = withJSON || key.IsJSON && key.GoType == ""
+
+    if key.goTypePackage != nil && !containsPackage(goTypesPackages, key.goTypePackage) {
+      goTypesPackages = append(goTypesPackages, key.goTypePackage)
+    }
+  }
+  if withJSON {
+    names = append(names, jsonPackageName)
+  }
+  if withTime {
+    names = append(names, timePackageName)
+  }
+  return append(buildPackagesByNames(names...), goTypesPackages...)
+}
+
+// validateGoTypes checks that go types packages aliases are not conflicted
+// with each other and with packages imported by accessors
+func validateGoTypes(accessorKeys []*groupKeyDesc) error {
+  importLines := map[string]string{
+    contextPackageName: packagesByNames[contextPackageName].ImportLine,
+    typesPackageName:   packagesByNames[typesPackageName].ImportLine,
+    jsonPackageName:    packagesByNames[jsonPackageName].ImportLine,
+    timePackageName:    packagesByNames[timePackageName].ImportLine,
+  }
+  for _, key := range accessorKeys {
+    pack := key.goTypePackage
+    if pack == nil {
+      continue
+    }
+    if !regexPackageAlias.MatchString(pack.ImportAlias) {
+      return fmt.Errorf("key %s: go type package %s name is not identifier: %s", key.KeyName, pack.ImportLine, pack.ImportAlias)
+    }
+    if importLine, ok := importLines[pack.ImportAlias]; ok && importLine != pack.ImportLine {
+      return fmt.Errorf("key %s: go type package %s conflicts with package: %s", key.KeyName, pack.ImportLine, importLine)
+    }
+    importLines[pack.ImportAlias] = pack.ImportLine
+  }
+  return nil
+}
+
+var regexPackageAlias = regexp.MustCompile(`^[a-zA-Z_]\w*$`)
+
+func containsPackage(packages []*goPackageDesc, pack *goPackageDesc) bool {
+  for _, other := range packages {
+    if other.ImportLine == pack.ImportLine {
+      return true
+    }
+  }
+  return false
+}
+
+// buildGoType returns go type qualified with package alias and package of the type
+func buildGoType(goType string) (string, *goPackageDesc) {
+  if goType == "" {
+    return "", nil
+  }
+  dot := strings.LastIndex(goType, ".")
+  importLine, typeName := goType[:dot], goType[dot+1:]
+  alias := filepath.Base(importLine)
+
+  return fmt.Sprint(alias, ".", typeName), &goPackageDesc{
+    CustomName:  importLine,
+    ImportLine:  importLine,
+    ImportAlias: alias,
   }
 }
 
@@ -77,26 +248,68 @@ func buildConfigGroups(customSection config.CustomSection) []*groupDesc {
     valueType := buildGroupKeyValueType(val.Type)
     valueCall := buildGroupKeyValueCall(val.Type)
 
+    goType, goTypePackage := buildGoType(val.GoType)
+    if goType != "" {
+      valueType = goType
+    }
+
     grKeys = append(grKeys, &groupKeyDesc{
-      KeyName:     keyName,
-      KeyNameTrim: keyNameTrim,
-      KeyComment:  keyComment,
-      ValueType:   valueType,
-      ValueCall:   valueCall,
+      KeyName:      keyName,
+      KeyNameTrim:  keyNameTrim,
+      KeyComment:   keyComment,
+      Type:         val.Type,
+      ValueType:    valueType,
+      ValueCall:    valueCall,
+      DefaultValue: val.Value,
+      RulesDesc:    buildGroupKeyRulesDesc(val.Rules),
+      // Encrypted secrets are not embedded into code
+      HasDefault: val.Type != secretValueType,
+      IsFlag:     val.Type == flagValueType,
+      IsJSON:     val.Type == jsonValueType,
+      GoType:     goType,
+
+      goTypePackage: goTypePackage,
     })
     groupsKeys[val.Group] = grKeys
   }
   configGroups := make([]*groupDesc, 0, len(groupsKeys))
 
   for groupName, groupKeys := range groupsKeys {
+    sort.Slice(groupKeys, func(i, j int) bool {
+      return groupKeys[i].KeyName < groupKeys[j].KeyName
+    })
     configGroups = append(configGroups, &groupDesc{
       GroupName: groupName,
       GroupKeys: groupKeys,
     })
   }
+  sort.Slice(configGroups, func(i, j int) bool {
+    return configGroups[i].GroupName < configGroups[j].GroupName
+  })
   return configGroups
 }
 
+func buildGroupKeyRulesDesc(rules *types.Rules) string {
+  if rules == nil {
+    return ""
+  }
+  var parts []string
+
+  if rules.Min != "" {
+    parts = append(parts, fmt.Sprintf("min: %s", rules.Min))
+  }
+  if rules.Max != "" {
+    parts = append(parts, fmt.Sprintf("max: %s", rules.Max))
+  }
+  if rules.Pattern != "" {
+    parts = append(parts, fmt.Sprintf("pattern: %s", rules.Pattern))
+  }
+  if len(rules.OneOf) > 0 {
+    parts = append(parts, fmt.Sprintf("one of: %s", strings.Join(rules.OneOf, ", ")))
+  }
+  return strings.Join(parts, "; ")
+}
+
 func buildGroupKeyNameTrim(key, group string) string {
   trim := strings.TrimPrefix(key, group)
   trim = strings.Trim(trim, "_- ")
@@ -111,6 +324,9 @@ func buildGroupKeyValueType(typ string) string {
 }
 
 func buildGroupKeyValueCall(typ string) string {
+  if valueCall, ok := valueTypeToValueCall[typ]; ok {
+    return valueCall
+  }
   return cases.Title(language.Und, cases.NoLower).String(typ)
 }
 
@@ -127,9 +343,28 @@ func buildPackagesByNames(names ...string) []*goPackageDesc {
   return packages
 }
 
+const (
+  flagValueType   = "flag"
+  jsonValueType   = "json"
+  secretValueType = "secret"
+)
+
 var valueTypeToPackageType = map[string]string{
   "time":     "time.Time",
   "duration": "time.Duration",
+  "flag":     "string",
+  "enum":     "string",
+  "json":     "json.RawMessage",
+  "secret":   "string",
+}
+
+var valueTypeToValueCall = map[string]string{
+  "flag":              "String",
+  "enum":              "String",
+  "secret":            "String",
+  "[]string":          "StringSlice",
+  "[]int":             "IntSlice",
+  "map[string]string": "StringMap",
 }
 
 var configPackages = []string{
@@ -150,12 +385,19 @@ var providerPackages = []string{
   typesPackageName,
 }
 
+var flagsPackages = []string{
+  contextPackageName,
+  flagsPackageName,
+}
+
 const (
   timePackageName    = "time"
+  jsonPackageName    = "json"
   contextPackageName = "context"
   atomicPackageName  = "atomic"
   configPackageName  = "config"
   typesPackageName   = "types"
+  flagsPackageName   = "flags"
 )
 
 var packagesByNames = map[string]*goPackageDesc{
@@ -164,6 +406,11 @@ var packagesByNames = map[string]*goPackageDesc{
     ImportLine: "time",
     IsBuiltin:  true,
   },
+  jsonPackageName: {
+    CustomName: "go/json",
+    ImportLine: "encoding/json",
+    IsBuiltin:  true,
+  },
   contextPackageName: {
     CustomName: "go/context",
     ImportLine: "context",
@@ -184,4 +431,9 @@ var packagesByNames = map[string]*goPackageDesc{
     ImportLine: "github.com/ushakovn/boiler/pkg/config/types",
     IsInstall:  true,
   },
+  flagsPackageName: {
+    CustomName: "boiler/flags",
+    ImportLine: "github.com/ushakovn/boiler/pkg/flags",
+    IsInstall:  true,
+  },
 }
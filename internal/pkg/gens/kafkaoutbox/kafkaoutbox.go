@@ -47,9 +47,13 @@ func NewKafkaoutbox(config Config) (*Kafkaoutbox, error) {
   if err != nil {
     return nil, err
   }
+  if config.ValidateProto && config.StorageConfigPath == "" {
+    return nil, fmt.Errorf("storage config path required for proto validation")
+  }
   var storageGen *storage.Storage
 
-  if config.ValidateProto {
+  // Storage dump resolves primary keys of the source tables for outbox record keys
+  if config.StorageConfigPath != "" {
     configPath := storage.ConfigPath(config.StorageConfigPath)
 
     if storageGen, err = storage.NewStorage(configPath); err != nil {
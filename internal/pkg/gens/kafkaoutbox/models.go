@@ -3,9 +3,12 @@ package kafkaoutbox
 import (
   "fmt"
   "path/filepath"
+  "strings"
 
+  "github.com/samber/lo"
   "github.com/ushakovn/boiler/internal/pkg/filext"
   "github.com/ushakovn/boiler/internal/pkg/goose"
+  "github.com/ushakovn/boiler/internal/pkg/pgdump"
   "github.com/ushakovn/boiler/internal/pkg/stringer"
   "github.com/ushakovn/boiler/templates"
 )
@@ -20,6 +23,7 @@ const (
   outboxTableFileName       = "kafka_outbox_table"
   outboxFuncFileName        = "kafka_outbox_func"
   outboxTriggerFileName     = "kafka_outbox_trigger"
+  outboxRecordKeyFileName   = "kafka_outbox_record_key"
 
   kafkaoutboxFileName = "kafkaoutbox"
   protoOptionFileName = "option"
@@ -67,6 +71,10 @@ type outboxTableDesc struct {
   OutboxTableName   string
   OutboxFuncName    string
   OutboxTriggerName string
+
+  // Record key sql expressions for the new and old rows
+  OutboxNewRecordKey string
+  OutboxOldRecordKey string
 }
 
 type protoFileDesc struct {
@@ -164,6 +172,15 @@ var migrationTableTemplates = []*templateDesc{
       return goose.BuildFileName(fileName)
     },
   },
+  {
+    name:       outboxRecordKeyFileName,
+    compiled:   templates.KafkaOutboxMigrationOutboxRecordKey,
+    buildCheck: buildCheckMigration,
+    buildFileName: func(tableName string) string {
+      fileName := fmt.Sprintf("%s_%s", outboxRecordKeyFileName, tableName)
+      return goose.BuildFileName(fileName)
+    },
+  },
 }
 
 var migrationCommonTemplates = []*templateDesc{
@@ -359,17 +376,27 @@ func (g *Kafkaoutbox) buildOutboxTables() ([]*outboxTableDesc, error) {
     return nil, fmt.Errorf("proto parsing failed: %w", err)
   }
 
-  if g.validateProto && g.storageGen != nil {
+  if g.validateProto {
     if err = validateProto(parsed, g.storageGen.DumpSQL()); err != nil {
       return nil, fmt.Errorf("proto validation failed: %w", err)
     }
   }
+  pkColumns, err := g.buildSourcePkColumns()
+  if err != nil {
+    return nil, fmt.Errorf("buildSourcePkColumns: %w", err)
+  }
+
   outboxTables := make([]*outboxTableDesc, 0, len(parsed.messages))
 
   for _, message := range parsed.messages {
     sourceTableName := message.tableName
     snakeTableName := stringer.StringToSnakeCase(sourceTableName)
 
+    tablePkColumns := pkColumns[sourceTableName]
+    if len(tablePkColumns) == 0 {
+      return nil, fmt.Errorf("primary key of the table %s not found in storage dump", sourceTableName)
+    }
+
     outboxTable := &outboxTableDesc{
       SourceTableName:   sourceTableName,
       OutboxProtoTyp:    buildOutboxProtoTyp(message.messageName),
@@ -377,12 +404,28 @@ func (g *Kafkaoutbox) buildOutboxTables() ([]*outboxTableDesc, error) {
       OutboxTableName:   buildOutboxTableName(snakeTableName),
       OutboxFuncName:    buildOutboxFuncName(snakeTableName),
       OutboxTriggerName: buildOutboxTriggerName(snakeTableName),
+
+      OutboxNewRecordKey: buildOutboxRecordKey("new", tablePkColumns),
+      OutboxOldRecordKey: buildOutboxRecordKey("old", tablePkColumns),
     }
     outboxTables = append(outboxTables, outboxTable)
   }
   return outboxTables, nil
 }
 
+// buildSourcePkColumns returns primary key columns by source tables
+// from the storage dump used for outbox record keys
+func (g *Kafkaoutbox) buildSourcePkColumns() (map[string][]string, error) {
+  if g.storageGen == nil {
+    return nil, fmt.Errorf("storage config path required to resolve primary keys of source tables")
+  }
+  pkColumns := lo.Associate(g.storageGen.DumpSQL().Tables.Elems(),
+    func(table *pgdump.DumpTable) (string, []string) {
+      return table.Name, table.PrimaryKey
+    })
+  return pkColumns, nil
+}
+
 func (g *Kafkaoutbox) buildProtoServicePath() string {
   serviceName := g.buildProtoServiceName()
 
@@ -416,6 +459,17 @@ func buildOutboxProtoTyp(messageName string) string {
   return fmt.Sprintf("desc.%s{}", messageName)
 }
 
+// buildOutboxRecordKey returns primary key columns of the row joined with colon
+func buildOutboxRecordKey(row string, pkColumns []string) string {
+  values := lo.Map(pkColumns, func(column string, _ int) string {
+    return fmt.Sprintf("%s.%s::text", row, column)
+  })
+  if len(values) == 1 {
+    return values[0]
+  }
+  return fmt.Sprintf("concat_ws(':', %s)", strings.Join(values, ", "))
+}
+
 func buildOutboxTableName(tableName string) string {
   return fmt.Sprintf("%s_outbox", tableName)
 }
@@ -131,6 +131,11 @@ func validateProto(parsed *parsedProto, dump *pgdump.DumpSQL) error {
 
       fieldTypes, isRepeated := pgTypeToProtoTypes(column.Typ)
 
+      // Enum labels are written as strings by row_to_json
+      if lo.ContainsBy(dump.Enums, func(enum *pgdump.DumpEnum) bool { return enum.Name == column.Typ }) {
+        fieldTypes = []string{"string"}
+      }
+
       if len(fieldTypes) == 0 {
         return fmt.Errorf("message: %s: field: %s: column type not supported: %s",
           message.messageName, field.fieldName, column.Typ)
@@ -14,10 +14,11 @@ type ConfigPath string
 
 // Config USE ConfigPath FOR GENERATORS FACTORY INSTEAD OF IT
 type Config struct {
-  PgConfig      *PgConfig                `yaml:"pg_config"`
-  PgDumpPath    string                   `yaml:"pg_dump_path"`
-  PgTableConfig *PgTableConfig           `yaml:"pg_table_config"`
-  PgTypeConfig  map[string]*PgTypeConfig `yaml:"pg_type_config"`
+  PgConfig           *PgConfig                           `yaml:"pg_config"`
+  PgDumpPath         string                              `yaml:"pg_dump_path"`
+  PgTableConfig      *PgTableConfig                      `yaml:"pg_table_config"`
+  PgTypeConfig       map[string]*PgTypeConfig            `yaml:"pg_type_config"`
+  PgColumnTypeConfig map[string]map[string]*PgTypeConfig `yaml:"pg_column_type_config"`
 }
 
 type PgConfig struct {
@@ -29,21 +30,51 @@ type PgConfig struct {
 }
 
 type PgTableConfig struct {
-  PgColumnFilter       *PgColFilter `yaml:"pg_column_filter"`
-  PgSkipTables         []string     `yaml:"pg_skip_tables"`
-  PgSkipCustomStorages []string     `yaml:"pg_skip_custom_storages"`
+  PgColumnFilter       *PgColFilter                 `yaml:"pg_column_filter"`
+  PgSkipTables         []string                     `yaml:"pg_skip_tables"`
+  PgSkipCustomStorages []string                     `yaml:"pg_skip_custom_storages"`
+  PgUpserts            map[string][]*PgUpsertConfig `yaml:"pg_upserts"`
+  PgTables             map[string]*PgTableOverride  `yaml:"pg_tables"`
+}
+
+// PgTableOverride overrides generated model of the table,
+// skipped methods are not generated for the model
+type PgTableOverride struct {
+  ModelName   string                       `yaml:"model_name"`
+  SkipMethods []string                     `yaml:"skip_methods"`
+  Columns     map[string]*PgColumnOverride `yaml:"columns"`
+}
+
+// PgColumnOverride skipped input columns are managed by database and not set
+// with generated inputs, read only columns are set on create only,
+// typed columns of both have no assignments for update queries
+type PgColumnOverride struct {
+  FieldName string `yaml:"field_name"`
+  SkipInput bool   `yaml:"skip_input"`
+  ReadOnly  bool   `yaml:"read_only"`
+}
+
+// PgUpsertConfig conflict target must match primary key or unique key columns,
+// all insert columns except conflict target and primary key are updated by default
+type PgUpsertConfig struct {
+  ConflictColumns []string `yaml:"conflict_columns"`
+  UpdateColumns   []string `yaml:"update_columns"`
 }
 
 type PgColFilter struct {
   AllByDefault bool                           `yaml:"all_by_default"`
   String       []string                       `yaml:"string"`
   Numeric      []string                       `yaml:"numeric"`
+  Array        []string                       `yaml:"array"`
   Overrides    map[string]map[string][]string `yaml:"overrides"`
 }
 
+// PgTypeConfig Go types of the not null and nullable columns,
+// types with package name are imported from go_import
 type PgTypeConfig struct {
   GoType     string `yaml:"go_type"`
   GoZeroType string `yaml:"go_zero_type"`
+  GoImport   string `yaml:"go_import"`
 }
 
 func (c ConfigPath) Parse() (*Config, error) {
@@ -113,6 +144,30 @@ func (c *Config) skipStorage(modelName string) bool {
   return lo.Contains(config, modelName)
 }
 
+func (c *Config) tableUpserts(tableName string) ([]*PgUpsertConfig, bool) {
+  upserts, ok := c.PgTableConfig.PgUpserts[tableName]
+  return upserts, ok
+}
+
+func (c *Config) tableOverride(tableName string) (*PgTableOverride, bool) {
+  override, ok := c.PgTableConfig.PgTables[tableName]
+  return override, ok
+}
+
+func (c *Config) columnOverride(tableName, columnName string) (*PgColumnOverride, bool) {
+  if override, ok := c.tableOverride(tableName); ok {
+    column, ok := override.Columns[columnName]
+    return column, ok
+  }
+  return nil, false
+}
+
+// columnType returns types of the column from pg column type config
+func (c *Config) columnType(tableName, columnName string) (*PgTypeConfig, bool) {
+  customTyp, ok := c.PgColumnTypeConfig[tableName][columnName]
+  return customTyp, ok
+}
+
 func (c *Config) skipTable(tableName string) bool {
   config := c.PgTableConfig.PgSkipTables
   return lo.Contains(config, tableName)
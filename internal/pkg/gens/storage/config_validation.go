@@ -2,6 +2,7 @@ package storage
 
 import (
   "fmt"
+  "regexp"
 
   validation "github.com/go-ozzo/ozzo-validation"
   "github.com/ushakovn/boiler/internal/pkg/filer"
@@ -16,6 +17,25 @@ const (
   pgColFilterOpNotIn   = "NotIn"
   pgColFilterOpLike    = "Like"
   pgColFilterOpNotLike = "NotLike"
+
+  pgColFilterOpILike       = "ILike"
+  pgColFilterOpNotILike    = "NotILike"
+  pgColFilterOpStartsWith  = "StartsWith"
+  pgColFilterOpIStartsWith = "IStartsWith"
+  pgColFilterOpContains    = "Contains"
+  pgColFilterOpIContains   = "IContains"
+  pgColFilterOpOverlaps    = "Overlaps"
+  pgColFilterOpRange       = "Range"
+  pgColFilterOpIsNull      = "IsNull"
+)
+
+const (
+  pgTableMethodGet    = "Get"
+  pgTableMethodList   = "List"
+  pgTableMethodCreate = "Create"
+  pgTableMethodUpdate = "Update"
+  pgTableMethodDelete = "Delete"
+  pgTableMethodUpsert = "Upsert"
 )
 
 func (c *Config) Validate() error {
@@ -39,6 +59,11 @@ func (c *Config) Validate() error {
       return fmt.Errorf("pg_type_config: %w", err)
     }
   }
+  for tableName, columns := range c.PgColumnTypeConfig {
+    if err := validation.Validate(columns, validation.Each(validation.Required)); err != nil {
+      return fmt.Errorf("pg_column_type_config: %s: %w", tableName, err)
+    }
+  }
   return nil
 }
 
@@ -63,19 +88,75 @@ func (c *PgTypeConfig) Validate() error {
 }
 
 func (c *PgTableConfig) Validate() error {
-  return validation.ValidateStruct(c,
+  if err := validation.ValidateStruct(c,
     validation.Field(&c.PgColumnFilter),
     validation.Field(&c.PgSkipTables, validation.Each(validation.Required)),
+  ); err != nil {
+    return err
+  }
+  for tableName, upserts := range c.PgUpserts {
+    if tableName == "" {
+      return fmt.Errorf("pg_upserts: table name cannot be blank")
+    }
+    for _, upsert := range upserts {
+      // Upsert config validated with Validate method
+      if err := validation.Validate(upsert, validation.Required); err != nil {
+        return fmt.Errorf("pg_upserts: %s: %w", tableName, err)
+      }
+    }
+  }
+  for tableName, override := range c.PgTables {
+    if tableName == "" {
+      return fmt.Errorf("pg_tables: table name cannot be blank")
+    }
+    // Table override validated with Validate method
+    if err := validation.Validate(override, validation.Required); err != nil {
+      return fmt.Errorf("pg_tables: %s: %w", tableName, err)
+    }
+  }
+  return nil
+}
+
+func (c *PgTableOverride) Validate() error {
+  if err := validation.ValidateStruct(c,
+    validation.Field(&c.ModelName, validation.Match(regexGoExportedName)),
+    validation.Field(&c.SkipMethods, validation.Each(validation.In(pgTableMethods...))),
+  ); err != nil {
+    return err
+  }
+  for columnName, column := range c.Columns {
+    if columnName == "" {
+      return fmt.Errorf("columns: column name cannot be blank")
+    }
+    if err := validation.Validate(column, validation.Required); err != nil {
+      return fmt.Errorf("columns: %s: %w", columnName, err)
+    }
+  }
+  return nil
+}
+
+func (c *PgColumnOverride) Validate() error {
+  return validation.ValidateStruct(c,
+    validation.Field(&c.FieldName, validation.Match(regexGoExportedName)),
+  )
+}
+
+func (c *PgUpsertConfig) Validate() error {
+  return validation.ValidateStruct(c,
+    validation.Field(&c.ConflictColumns, validation.Required, validation.Each(validation.Required)),
+    validation.Field(&c.UpdateColumns, validation.Each(validation.Required)),
   )
 }
 
 func (c *PgColFilter) Validate() error {
   ruleString := validation.Each(validation.In(pgColStringOperators...))
   ruleNumeric := validation.Each(validation.In(pgColNumericOperators...))
+  ruleArray := validation.Each(validation.In(pgColArrayOperators...))
 
   if err := validation.ValidateStruct(c,
     validation.Field(&c.String, ruleString),
     validation.Field(&c.Numeric, ruleNumeric),
+    validation.Field(&c.Array, ruleArray),
   ); err != nil {
     return err
   }
@@ -97,6 +178,17 @@ func (c *PgColFilter) Validate() error {
   return nil
 }
 
+var pgTableMethods = []any{
+  pgTableMethodGet,
+  pgTableMethodList,
+  pgTableMethodCreate,
+  pgTableMethodUpdate,
+  pgTableMethodDelete,
+  pgTableMethodUpsert,
+}
+
+var regexGoExportedName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
 var pgColOperators = []any{
   pgColFilterOpIn,
   pgColFilterOpNotIn,
@@ -108,6 +200,15 @@ var pgColOperators = []any{
   pgColFilterOpLtOrEq,
   pgColFilterOpIn,
   pgColFilterOpNotIn,
+  pgColFilterOpILike,
+  pgColFilterOpNotILike,
+  pgColFilterOpStartsWith,
+  pgColFilterOpIStartsWith,
+  pgColFilterOpContains,
+  pgColFilterOpIContains,
+  pgColFilterOpOverlaps,
+  pgColFilterOpRange,
+  pgColFilterOpIsNull,
 }
 
 var pgColStringOperators = []any{
@@ -115,8 +216,16 @@ var pgColStringOperators = []any{
   pgColFilterOpNotIn,
   pgColFilterOpLike,
   pgColFilterOpNotLike,
+  pgColFilterOpILike,
+  pgColFilterOpNotILike,
+  pgColFilterOpStartsWith,
+  pgColFilterOpIStartsWith,
+  pgColFilterOpContains,
+  pgColFilterOpIContains,
+  pgColFilterOpIsNull,
 }
 
+// Range is used for time columns only
 var pgColNumericOperators = []any{
   pgColFilterOpGt,
   pgColFilterOpGtOrEq,
@@ -124,4 +233,12 @@ var pgColNumericOperators = []any{
   pgColFilterOpLtOrEq,
   pgColFilterOpIn,
   pgColFilterOpNotIn,
+  pgColFilterOpRange,
+  pgColFilterOpIsNull,
+}
+
+var pgColArrayOperators = []any{
+  pgColFilterOpContains,
+  pgColFilterOpOverlaps,
+  pgColFilterOpIsNull,
 }
@@ -0,0 +1,63 @@
+package storage
+
+import (
+  "fmt"
+
+  "github.com/ushakovn/boiler/internal/pkg/pgdump"
+  "github.com/ushakovn/boiler/internal/pkg/stringer"
+)
+
+type enumDesc struct {
+  EnumName     string
+  NullEnumName string
+  SqlTypeName  string
+  EnumValues   []*enumValueDesc
+}
+
+type enumValueDesc struct {
+  ValueName string
+  Value     string
+}
+
+// buildEnums returns enums by pg type names, enums overridden
+// with pg type config are not generated
+func (g *Storage) buildEnums(enums []*pgdump.DumpEnum) ([]*enumDesc, map[string]*enumDesc, error) {
+  descs := make([]*enumDesc, 0, len(enums))
+  descsByTypes := make(map[string]*enumDesc, len(enums))
+
+  for _, enum := range enums {
+    if _, ok := g.config.PgTypeConfig[enum.Name]; ok {
+      continue
+    }
+    enumName := stringer.StringToUpperCamelCase(enum.Name)
+
+    desc := &enumDesc{
+      EnumName:     enumName,
+      NullEnumName: buildNullEnumTyp(enumName),
+      SqlTypeName:  enum.Name,
+      EnumValues:   make([]*enumValueDesc, 0, len(enum.Values)),
+    }
+    valueNames := make(map[string]struct{}, len(enum.Values))
+
+    for _, value := range enum.Values {
+      valueName := enumName + stringer.StringToUpperCamelCase(value)
+
+      if _, ok := valueNames[valueName]; ok || valueName == enumName {
+        return nil, nil, fmt.Errorf("enum %s: value %q: invalid or duplicated constant name: %s", enum.Name, value, valueName)
+      }
+      valueNames[valueName] = struct{}{}
+
+      desc.EnumValues = append(desc.EnumValues, &enumValueDesc{
+        ValueName: valueName,
+        Value:     value,
+      })
+    }
+    descs = append(descs, desc)
+    descsByTypes[enum.Name] = desc
+  }
+  return descs, descsByTypes, nil
+}
+
+func buildNullEnumTyp(enumName string) string {
+  return nullEnumTypPrefix + enumName
+}
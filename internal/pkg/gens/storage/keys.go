@@ -0,0 +1,182 @@
+package storage
+
+import (
+  "fmt"
+  "sort"
+  "strings"
+
+  "github.com/samber/lo"
+  "github.com/ushakovn/boiler/internal/pkg/pgdump"
+  "github.com/ushakovn/boiler/templates"
+)
+
+type uniqueKeyDesc struct {
+  KeyName   string
+  KeyFields []*fieldDesc
+}
+
+type upsertDesc struct {
+  UpsertName   string
+  UpsertSuffix string
+}
+
+// buildPkFields returns primary key fields in the constraint columns order
+func buildPkFields(table *pgdump.DumpTable, fields []*fieldDesc) ([]*fieldDesc, error) {
+  pkFields := make([]*fieldDesc, 0, len(table.PrimaryKey))
+
+  for _, column := range table.PrimaryKey {
+    field, ok := findField(fields, column)
+    if !ok {
+      return nil, fmt.Errorf("primary key column not found: %s", column)
+    }
+    pkFields = append(pkFields, field)
+  }
+  return pkFields, nil
+}
+
+// buildUniqueKeys returns primary key as the first key and unique keys of the table
+func buildUniqueKeys(table *pgdump.DumpTable, fields, pkFields []*fieldDesc) ([]*uniqueKeyDesc, error) {
+  keys := []*uniqueKeyDesc{
+    buildUniqueKey(pkFields),
+  }
+  for _, uniqueKey := range table.UniqueKeys {
+    keyFields := make([]*fieldDesc, 0, len(uniqueKey.Columns))
+
+    for _, column := range uniqueKey.Columns {
+      field, ok := findField(fields, column)
+      if !ok {
+        return nil, fmt.Errorf("unique key %s: column not found: %s", uniqueKey.Name, column)
+      }
+      keyFields = append(keyFields, field)
+    }
+    key := buildUniqueKey(keyFields)
+
+    // Unique key with primary key columns is skipped
+    if lo.ContainsBy(keys, func(k *uniqueKeyDesc) bool { return k.KeyName == key.KeyName }) {
+      continue
+    }
+    keys = append(keys, key)
+  }
+  return keys, nil
+}
+
+func buildUniqueKey(fields []*fieldDesc) *uniqueKeyDesc {
+  var keyName string
+
+  for _, field := range fields {
+    keyName += field.FieldName
+  }
+  return &uniqueKeyDesc{
+    KeyName:   keyName,
+    KeyFields: fields,
+  }
+}
+
+// buildUpserts returns upserts of the table from config, upserts on each
+// key without default columns are used for tables not found in config
+func (g *Storage) buildUpserts(tableName string, fields []*fieldDesc, keys []*uniqueKeyDesc) ([]*upsertDesc, error) {
+  if override, ok := g.config.tableOverride(tableName); ok && lo.Contains(override.SkipMethods, pgTableMethodUpsert) {
+    return nil, nil
+  }
+  configs, ok := g.config.tableUpserts(tableName)
+
+  if !ok {
+    for _, key := range keys {
+      if lo.SomeBy(key.KeyFields, func(field *fieldDesc) bool { return !field.CreateInputField }) {
+        continue
+      }
+      configs = append(configs, &PgUpsertConfig{
+        ConflictColumns: fieldsColumns(key.KeyFields),
+      })
+    }
+  }
+  // Insert columns of the Create input
+  insertColumns := fieldsColumns(lo.Filter(fields, func(field *fieldDesc, _ int) bool {
+    return field.CreateInputField
+  }))
+  // Read only columns are not updated
+  readOnlyColumns := fieldsColumns(lo.Filter(fields, func(field *fieldDesc, _ int) bool {
+    return field.CreateInputField && !field.UpdateInputField && field.FieldBadge != fieldBadgePk
+  }))
+  pkColumns := fieldsColumns(keys[0].KeyFields)
+
+  upserts := make([]*upsertDesc, 0, len(configs))
+
+  for _, config := range configs {
+    key, ok := lo.Find(keys, func(key *uniqueKeyDesc) bool {
+      return equalColumns(fieldsColumns(key.KeyFields), config.ConflictColumns)
+    })
+    if !ok {
+      return nil, fmt.Errorf("upsert on %v: primary key or unique key not found", config.ConflictColumns)
+    }
+    conflictColumns := fieldsColumns(key.KeyFields)
+
+    for _, column := range conflictColumns {
+      if !lo.Contains(insertColumns, column) {
+        return nil, fmt.Errorf("upsert on %v: conflict column not found in insert columns: %s", config.ConflictColumns, column)
+      }
+    }
+    updateColumns := config.UpdateColumns
+
+    if len(updateColumns) == 0 {
+      // Primary key columns are not updated by default
+      updateColumns = lo.Filter(insertColumns, func(column string, _ int) bool {
+        return !lo.Contains(conflictColumns, column) && !lo.Contains(pkColumns, column) && !lo.Contains(readOnlyColumns, column)
+      })
+    }
+    for _, column := range updateColumns {
+      if !lo.Contains(insertColumns, column) {
+        return nil, fmt.Errorf("upsert on %v: update column not found in insert columns: %s", config.ConflictColumns, column)
+      }
+      if lo.Contains(readOnlyColumns, column) {
+        return nil, fmt.Errorf("upsert on %v: update column is read only: %s", config.ConflictColumns, column)
+      }
+    }
+    // Conflict column updated with the same value to return the conflicting row
+    if len(updateColumns) == 0 {
+      updateColumns = conflictColumns[:1]
+    }
+    upsertName := "On" + key.KeyName
+
+    if lo.ContainsBy(upserts, func(upsert *upsertDesc) bool { return upsert.UpsertName == upsertName }) {
+      return nil, fmt.Errorf("upsert on %v: duplicated conflict columns", config.ConflictColumns)
+    }
+    updateSets := lo.Map(updateColumns, func(column string, _ int) string {
+      return fmt.Sprintf(templates.StorageModelUpsertSet, column)
+    })
+    upsertSuffix := fmt.Sprintf(templates.StorageModelUpsertSuffix,
+      strings.Join(conflictColumns, ", "),
+      strings.Join(updateSets, ", "),
+    )
+    upserts = append(upserts, &upsertDesc{
+      UpsertName:   upsertName,
+      UpsertSuffix: upsertSuffix,
+    })
+  }
+  return upserts, nil
+}
+
+func findField(fields []*fieldDesc, column string) (*fieldDesc, bool) {
+  return lo.Find(fields, func(field *fieldDesc) bool {
+    return field.SqlTableFieldName == column
+  })
+}
+
+func fieldsColumns(fields []*fieldDesc) []string {
+  return lo.Map(fields, func(field *fieldDesc, _ int) string {
+    return field.SqlTableFieldName
+  })
+}
+
+func equalColumns(columns1, columns2 []string) bool {
+  if len(columns1) != len(columns2) {
+    return false
+  }
+  sorted1 := append([]string(nil), columns1...)
+  sorted2 := append([]string(nil), columns2...)
+
+  sort.Strings(sorted1)
+  sort.Strings(sorted2)
+
+  return strings.Join(sorted1, ",") == strings.Join(sorted2, ",")
+}
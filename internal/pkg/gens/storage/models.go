@@ -13,20 +13,42 @@ import (
 )
 
 type schemaDesc struct {
-  Models          []*modelDesc
-  StoragePackages []*goPackageDesc
-  OptionsPackages []*goPackageDesc
+  Models             []*modelDesc
+  Relations          []*Relation
+  Enums              []*enumDesc
+  StoragePackages    []*goPackageDesc
+  OptionsPackages    []*goPackageDesc
+  QueryPackages      []*goPackageDesc
+  EnumsPackages      []*goPackageDesc
+  ModelEnumsPackages []*goPackageDesc
 }
 
 type modelDesc struct {
   ModelName            string
   SqlTableName         string
   ModelFields          []*fieldDesc
+  ModelPkFields        []*fieldDesc
+  ModelAggregateFields []*fieldDesc
+  ModelKeyComparable   bool
+  ModelUniqueKeys      []*uniqueKeyDesc
+  ModelUpserts         []*upsertDesc
+  ModelLoaders         []*relationDesc
+  ModelEagerRelations  []*relationDesc
+  ModelMethods         *modelMethodsDesc
   ModelPackages        []*goPackageDesc
   ModelOptionsPackages []*goPackageDesc
   ModelMethodsPackages []*goPackageDesc
 }
 
+// modelMethodsDesc generated methods of the model
+type modelMethodsDesc struct {
+  Get    bool
+  List   bool
+  Create bool
+  Update bool
+  Delete bool
+}
+
 type fieldDesc struct {
   // Sql column field attributes
   SqlTableFieldName string
@@ -34,11 +56,19 @@ type fieldDesc struct {
   WithDefaultField  bool
   FieldBadge        string
 
+  // Input field attributes
+  CreateInputField bool
+  UpdateInputField bool
+
+  // Column is not assigned by update queries
+  ReadOnlyField bool
+
   // Core field attributes
   FieldName       string
   FieldType       string
   FieldTypeSuffix string
   FieldIfStmt     string
+  FieldNullStmt   string
 
   // Builtin field attributes
   FieldBuiltinType string
@@ -51,6 +81,13 @@ type fieldDesc struct {
   // Filter field attributes
   ModelFieldFilters  []*fieldFilterDesc
   ModelsFieldFilters []*fieldFilterDesc
+
+  // Packages of the field types
+  FieldPackages []*goPackageDesc
+
+  // Typed column of the field used in queries
+  FieldColumnType string
+  FieldColumnCtor string
 }
 
 type fieldFilterDesc struct {
@@ -59,11 +96,20 @@ type fieldFilterDesc struct {
   FilterIfStmt     string
   FilterSqOperator string
   FilterTypeSuffix string
+  // Function returning condition used instead of squirrel operator
+  FilterSqFunc string
 }
 
 func (g *Storage) loadSchemaDesc() error {
+  enums, enumsByTypes, err := g.buildEnums(g.dumpSQL.Enums)
+  if err != nil {
+    return fmt.Errorf("buildEnums: %w", err)
+  }
+  g.enumsByTypes = enumsByTypes
+
   tables := g.dumpSQL.Tables.Elems()
   models := make([]*modelDesc, 0, len(tables))
+  modelsByTables := make(map[string]*modelDesc, len(tables))
 
   for _, table := range tables {
     if g.config.skipTable(table.Name) {
@@ -77,56 +123,123 @@ func (g *Storage) loadSchemaDesc() error {
       if err != nil {
         return fmt.Errorf("tableColumnToFieldDesc: %w", err)
       }
+      if other, ok := lo.Find(fields, func(other *fieldDesc) bool { return other.FieldName == field.FieldName }); ok {
+        return fmt.Errorf("table %s: column %s: field name conflicts with column: %s", table.Name, column.Name, other.SqlTableFieldName)
+      }
       fields = append(fields, field)
     }
-    modelName := buildModelName(table.Name)
-    modelPackages := buildFilePackages(modelsFileName)
+    fieldsPackages := uniqGoPackages(lo.FlatMap(fields, func(field *fieldDesc, _ int) []*goPackageDesc {
+      return field.FieldPackages
+    }))
+    pkFields, err := buildPkFields(table, fields)
+    if err != nil {
+      return fmt.Errorf("buildPkFields: table %s: %w", table.Name, err)
+    }
+    uniqueKeys, err := buildUniqueKeys(table, fields, pkFields)
+    if err != nil {
+      return fmt.Errorf("buildUniqueKeys: %w", err)
+    }
+    modelMethods := g.buildModelMethods(table.Name)
 
+    upserts, err := g.buildUpserts(table.Name, fields, uniqueKeys)
+    if err != nil {
+      return fmt.Errorf("buildUpserts: table %s: %w", table.Name, err)
+    }
+    modelName := g.buildModelName(table.Name)
+
+    for _, field := range fields {
+      field.FieldColumnType, field.FieldColumnCtor = buildFieldColumnTyp(modelName, field.FieldBuiltinType, field.ReadOnlyField)
+    }
+    modelPackages := mergeGoPackages(
+      buildFilePackages(modelsFileName),
+      fieldsPackages,
+    )
     modelOptionsPackages := mergeGoPackages(
       buildFilePackages(modelOptionsFileName),
       buildCrossFilePackages(g.goModuleName, modelOptionsFileName),
+      fieldsPackages,
     )
     modelMethodsPackages := mergeGoPackages(
       buildFilePackages(modelMethodsFileName),
       buildCrossFilePackages(g.goModuleName, modelMethodsFileName),
+      fieldsPackages,
     )
-    models = append(models, &modelDesc{
+    model := &modelDesc{
       SqlTableName: table.Name,
 
-      ModelName:   modelName,
-      ModelFields: fields,
+      ModelName:     modelName,
+      ModelFields:   fields,
+      ModelPkFields: pkFields,
+
+      // Numeric fields aggregated with sum, min and max
+      ModelAggregateFields: lo.Filter(fields, func(field *fieldDesc, _ int) bool {
+        return matchAggregateTyp(field.FieldType)
+      }),
+
+      // Key struct with slice fields cannot be used as a map key
+      ModelKeyComparable: !lo.SomeBy(pkFields, func(field *fieldDesc) bool {
+        return !matchComparableTyp(field.FieldType)
+      }),
+
+      ModelUniqueKeys: uniqueKeys,
+      ModelUpserts:    upserts,
+      ModelMethods:    modelMethods,
 
       ModelPackages:        modelPackages,
       ModelOptionsPackages: modelOptionsPackages,
       ModelMethodsPackages: modelMethodsPackages,
-    })
+    }
+    if enum, ok := lo.Find(enums, func(enum *enumDesc) bool { return enum.EnumName == modelName }); ok {
+      return fmt.Errorf("model %s: name conflicts with enum type: %s", modelName, enum.SqlTypeName)
+    }
+    if other, ok := lo.Find(models, func(other *modelDesc) bool { return other.ModelName == modelName }); ok {
+      return fmt.Errorf("model %s: name conflicts with table: %s", modelName, other.SqlTableName)
+    }
+    models = append(models, model)
+    modelsByTables[table.RawName] = model
+  }
+  relations, err := buildRelations(tables, modelsByTables)
+  if err != nil {
+    return fmt.Errorf("buildRelations: %w", err)
   }
 
   g.schemaDesc = &schemaDesc{
     Models:          models,
+    Relations:       relations,
+    Enums:           enums,
     StoragePackages: buildFilePackages(storageFileName),
     OptionsPackages: buildFilePackages(optionsFileName),
+    QueryPackages:   buildFilePackages(queryFileName),
+    EnumsPackages: mergeGoPackages(
+      buildFilePackages(enumsFileName),
+      buildCrossFilePackages(g.goModuleName, enumsFileName),
+    ),
+    ModelEnumsPackages: buildFilePackages(modelEnumsFileName),
   }
   return nil
 }
 
 func (g *Storage) tableColumnToFieldDesc(tableName string, column *pgdump.DumpColumn) (*fieldDesc, error) {
   fieldName := stringer.StringToUpperCamelCase(column.Name)
+  columnOverride, ok := g.config.columnOverride(tableName, column.Name)
 
-  fieldZeroTyp, ok := g.columnNullableTypToFieldTyp(column.Typ)
   if !ok {
-    return nil, fmt.Errorf("field zero type not found for column: %s type: %s", column.Name, column.Typ)
+    columnOverride = &PgColumnOverride{}
+  }
+  if columnOverride.FieldName != "" {
+    fieldName = columnOverride.FieldName
   }
 
-  fieldBuiltinTyp, ok := g.columnNotNullToFieldTypMapping(column.Typ)
-  if !ok {
-    return nil, fmt.Errorf("field builtin type not found for column: %s type: %s", column.Name, column.Typ)
+  fieldZeroTyp, fieldBuiltinTyp, err := g.columnFieldTyps(tableName, column)
+  if err != nil {
+    return nil, err
   }
 
   fieldTyp := lo.Ternary(column.IsNotNull, fieldBuiltinTyp, fieldZeroTyp)
   fieldBadge := lo.Ternary(column.IsPrimaryKey, fieldBadgePk, "")
 
   fieldIfStmt := buildFieldIfStmt(fieldName, fieldTyp)
+  fieldNullStmt := buildFieldNullStmt(fieldName, fieldTyp)
   fieldTypSuffix := buildFieldTypeSuffix(fieldTyp)
 
   fieldZeroTypIfStmt := buildFieldIfStmt(fieldName, fieldZeroTyp)
@@ -135,6 +248,7 @@ func (g *Storage) tableColumnToFieldDesc(tableName string, column *pgdump.DumpCo
   output := g.buildFieldFilters(buildFieldFiltersInput{
     tableName:       tableName,
     columnName:      column.Name,
+    fieldNotNull:    column.IsNotNull,
     fieldName:       fieldName,
     fieldTyp:        fieldTyp,
     fieldZeroTyp:    fieldZeroTyp,
@@ -147,9 +261,16 @@ func (g *Storage) tableColumnToFieldDesc(tableName string, column *pgdump.DumpCo
     WithDefaultField:  column.WithDefault,
     FieldBadge:        fieldBadge,
 
+    // Columns with default are set by database on create
+    CreateInputField: !column.WithDefault && !columnOverride.SkipInput,
+    UpdateInputField: !column.IsPrimaryKey && !columnOverride.SkipInput && !columnOverride.ReadOnly,
+
+    ReadOnlyField: columnOverride.SkipInput || columnOverride.ReadOnly,
+
     FieldName:       fieldName,
     FieldType:       fieldTyp,
     FieldIfStmt:     fieldIfStmt,
+    FieldNullStmt:   fieldNullStmt,
     FieldTypeSuffix: fieldTypSuffix,
 
     FieldBuiltinType: fieldBuiltinTyp,
@@ -160,18 +281,131 @@ func (g *Storage) tableColumnToFieldDesc(tableName string, column *pgdump.DumpCo
 
     ModelFieldFilters:  output.model,
     ModelsFieldFilters: output.models,
+
+    FieldPackages: g.buildFieldPackages(tableName, column, fieldTyp, fieldZeroTyp),
   }, nil
 }
 
-func buildModelName(tableName string) string {
+// buildFieldColumnTyp returns typed column and its constructor with values of the field builtin type,
+// read only columns have no assignments for update queries
+func buildFieldColumnTyp(modelName, fieldBuiltinTyp string, readOnly bool) (columnTyp, columnCtor string) {
+  modelTyp := fmt.Sprint(modelsFileName, ".", modelName)
+
+  switch {
+  case fieldBuiltinTyp == "string":
+    columnTyp = fmt.Sprintf("StringColumn[%s]", modelTyp)
+
+  case matchArrayTyp(fieldBuiltinTyp):
+    elemTyp := strings.TrimPrefix(fieldBuiltinTyp, sliceTypPrefix)
+    columnTyp = fmt.Sprintf("ArrayColumn[%s, %s]", modelTyp, elemTyp)
+
+  default:
+    columnTyp = fmt.Sprintf("Column[%s, %s]", modelTyp, fieldBuiltinTyp)
+  }
+  if readOnly {
+    columnTyp = readOnlyColumnTypPrefix + columnTyp
+  }
+  columnCtor = "new" + columnTyp
+  return columnTyp, columnCtor
+}
+
+// columnFieldTyps returns nullable and not null field types of the column,
+// column type config overrides type config and default types
+func (g *Storage) columnFieldTyps(tableName string, column *pgdump.DumpColumn) (zeroTyp, builtinTyp string, err error) {
+  if customTyp, ok := g.config.columnType(tableName, column.Name); ok {
+    zeroTyp, builtinTyp = customTyp.GoZeroType, customTyp.GoType
+  } else {
+    if zeroTyp, ok = g.columnNullableTypToFieldTyp(column.Typ); !ok {
+      return "", "", fmt.Errorf("field zero type not found for column: %s type: %s", column.Name, column.Typ)
+    }
+    if builtinTyp, ok = g.columnNotNullToFieldTypMapping(column.Typ); !ok {
+      return "", "", fmt.Errorf("field builtin type not found for column: %s type: %s", column.Name, column.Typ)
+    }
+  }
+  // Generated models package imported in the same files
+  for _, typ := range []string{zeroTyp, builtinTyp} {
+    if typPackageName(typ) == modelsFileName {
+      return "", "", fmt.Errorf("field type for column: %s: package name conflicts with generated package: %s", column.Name, typ)
+    }
+  }
+  return zeroTyp, builtinTyp, nil
+}
+
+// buildFieldPackages returns packages of the field types except
+// zero and time packages imported by default
+func (g *Storage) buildFieldPackages(tableName string, column *pgdump.DumpColumn, fieldTyps ...string) []*goPackageDesc {
+  customTyp, ok := g.config.columnType(tableName, column.Name)
+  if !ok {
+    customTyp, ok = g.config.PgTypeConfig[column.Typ]
+  }
+  if ok && customTyp.GoImport != "" {
+    return []*goPackageDesc{
+      {
+        CustomName:  customTyp.GoImport,
+        ImportLine:  customTyp.GoImport,
+        ImportAlias: typPackageName(customTyp.GoType),
+        IsInstall:   true,
+      },
+    }
+  }
+  var fieldPackages []*goPackageDesc
+
+  for _, fieldTyp := range fieldTyps {
+    switch typPackageName(fieldTyp) {
+    case pgtypePackageName:
+      fieldPackages = append(fieldPackages, importPackagesByNames[pgtypePackageName])
+    case netipPackageName:
+      fieldPackages = append(fieldPackages, importPackagesByNames[netipPackageName])
+    }
+  }
+  return fieldPackages
+}
+
+// typPackageName returns package name of the type like []*pkg.Typ
+func typPackageName(typ string) string {
+  typ = strings.TrimLeft(typ, "[]*")
+
+  if packageName, _, ok := strings.Cut(typ, "."); ok {
+    return packageName
+  }
+  return ""
+}
+
+func uniqGoPackages(goPackages []*goPackageDesc) []*goPackageDesc {
+  return lo.UniqBy(goPackages, func(goPackage *goPackageDesc) string {
+    return goPackage.ImportLine
+  })
+}
+
+func (g *Storage) buildModelName(tableName string) string {
+  if override, ok := g.config.tableOverride(tableName); ok && override.ModelName != "" {
+    return override.ModelName
+  }
   tableName = stringer.StringToUpperCamelCase(tableName)
   modelName := stringer.NormalizeName(tableName)
   return modelName
 }
 
+// buildModelMethods returns methods of the model except skipped with table config
+func (g *Storage) buildModelMethods(tableName string) *modelMethodsDesc {
+  var skipMethods []string
+
+  if override, ok := g.config.tableOverride(tableName); ok {
+    skipMethods = override.SkipMethods
+  }
+  return &modelMethodsDesc{
+    Get:    !lo.Contains(skipMethods, pgTableMethodGet),
+    List:   !lo.Contains(skipMethods, pgTableMethodList),
+    Create: !lo.Contains(skipMethods, pgTableMethodCreate),
+    Update: !lo.Contains(skipMethods, pgTableMethodUpdate),
+    Delete: !lo.Contains(skipMethods, pgTableMethodDelete),
+  }
+}
+
 type buildFieldFiltersInput struct {
   tableName       string
   columnName      string
+  fieldNotNull    bool
   fieldName       string
   fieldTyp        string
   fieldZeroTyp    string
@@ -185,23 +419,37 @@ type buildFieldFiltersOutput struct {
 
 func (g *Storage) buildFieldFilters(input buildFieldFiltersInput) (output buildFieldFiltersOutput) {
   var (
-    model  []*fieldFilterDesc
-    models []*fieldFilterDesc
+    model     []*fieldFilterDesc
+    models    []*fieldFilterDesc
+    operators []string
   )
   switch {
-  case matchNumericTyp(input.fieldTyp), matchZeroNumericTyp(input.fieldTyp), matchTimeTyp(input.fieldTyp):
+  case matchNumericTyp(input.fieldTyp), matchZeroNumericTyp(input.fieldTyp), matchDecimalTyp(input.fieldTyp), matchTimeTyp(input.fieldTyp):
     // Filters for model method
     model = g.buildNumericFilters(modelNumericFilterOperators, input.fieldName, input.fieldZeroTyp, input.fieldBuiltinTyp)
 
-    operators := g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypNumeric)
+    filterField := lo.Ternary(matchTimeTyp(input.fieldTyp), filterFieldTypTime, filterFieldTypNumeric)
+    operators = g.buildFilterOperators(input.tableName, input.columnName, filterField)
     // Filters for list method
     models = g.buildNumericFilters(operators, input.fieldName, input.fieldZeroTyp, input.fieldBuiltinTyp)
 
+    if filterField == filterFieldTypTime {
+      models = append(models, buildTimeRangeFilters(operators, input.fieldName)...)
+    }
+
   case matchStringTyp(input.fieldTyp):
     // Filters for model method
     model = g.buildStringFilters(modelStringFilterOperators, input.fieldName, input.fieldZeroTyp, input.fieldBuiltinTyp)
 
-    operators := g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypString)
+    operators = g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypString)
+    // Filters for list method
+    models = g.buildStringFilters(operators, input.fieldName, input.fieldZeroTyp, input.fieldBuiltinTyp)
+
+  case g.matchEnumTyp(input.fieldBuiltinTyp):
+    // Filters for model method
+    model = g.buildStringFilters(modelStringFilterOperators, input.fieldName, input.fieldZeroTyp, input.fieldBuiltinTyp)
+
+    operators = g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypEnum)
     // Filters for list method
     models = g.buildStringFilters(operators, input.fieldName, input.fieldZeroTyp, input.fieldBuiltinTyp)
 
@@ -210,6 +458,19 @@ func (g *Storage) buildFieldFilters(input buildFieldFiltersInput) (output buildF
     model = g.buildBoolFilters(input.fieldName, input.fieldZeroTyp)
     // Filters for list method
     models = g.buildBoolFilters(input.fieldName, input.fieldZeroTyp)
+
+    operators = g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypOther)
+
+  case matchArrayTyp(input.fieldTyp):
+    operators = g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypArray)
+    // Filters for list method
+    models = buildArrayFilters(operators, input.fieldName, input.fieldBuiltinTyp)
+
+  default:
+    operators = g.buildFilterOperators(input.tableName, input.columnName, filterFieldTypOther)
+  }
+  if !input.fieldNotNull {
+    models = append(models, buildNullFilters(operators, input.fieldName)...)
   }
   return buildFieldFiltersOutput{
     model:  model,
@@ -227,13 +488,23 @@ func (g *Storage) buildStringFilters(stringFilterOperators []string, fieldName,
     filterTypSuffix := buildFieldTypeSuffix(filterTyp)
     filterIfStmt := buildStringFilterIfStmt(filterName, filterOperator)
     filterSqOperator := buildStringFilterSqOperator(filterOperator)
+    filterSqFunc := buildStringFilterSqFunc(filterOperator)
 
+    // Operators of the other field types are skipped
+    if filterSqOperator == "" && filterSqFunc == "" {
+      continue
+    }
+    // Pattern operators are not supported for enums
+    if filterSqFunc != "" && g.matchEnumTyp(fieldBuiltinTyp) {
+      continue
+    }
     stringFilters = append(stringFilters, &fieldFilterDesc{
       FilterName:       filterName,
       FilterType:       filterTyp,
       FilterIfStmt:     filterIfStmt,
       FilterSqOperator: filterSqOperator,
       FilterTypeSuffix: filterTypSuffix,
+      FilterSqFunc:     filterSqFunc,
     })
   }
   return stringFilters
@@ -266,9 +537,13 @@ func (g *Storage) buildNumericFilters(numericFilterOperators []string, fieldName
     filterTyp := buildNumericFilterType(fieldZeroTyp, fieldBuiltinTyp, filterOperator)
     // Filter type suffix at the same that field type suffix
     filterTypSuffix := buildFieldTypeSuffix(filterTyp)
-    filterIfStmt := buildNumericFilterIfStmt(filterName, filterOperator)
+    filterIfStmt := buildNumericFilterIfStmt(filterName, filterTyp, filterOperator)
     filterSqOperator := buildNumericFilterSqOperator(filterOperator)
 
+    // Operators of the other field types are skipped
+    if filterSqOperator == "" {
+      continue
+    }
     numericFilters = append(numericFilters, &fieldFilterDesc{
       FilterName:       filterName,
       FilterType:       filterTyp,
@@ -298,23 +573,36 @@ func (g *Storage) columnNotNullToFieldTypMapping(columnTyp string) (string, bool
     "bool":    "bool",
     "boolean": "bool",
 
-    "money":   "float64",
-    "real":    "float32",
-    "float":   "float32",
-    "double":  "float64",
-    "decimal": "float64",
-    "numeric": "float64",
+    "money":  "float64",
+    "real":   "float32",
+    "float":  "float32",
+    "double": "float64",
+
+    // Exact decimal column types
+
+    "decimal": "pgtype.Numeric",
+    "numeric": "pgtype.Numeric",
 
     "varchar":   "string",
     "varying":   "string",
     "character": "string",
 
-    "uuid": "string",
-    "text": "string",
+    "uuid":   "string",
+    "text":   "string",
+    "citext": "string",
+    "ltree":  "string",
+
+    "date":        "time.Time",
+    "time":        "time.Time",
+    "timestamp":   "time.Time",
+    "timestamptz": "time.Time",
+
+    "interval": "pgtype.Interval",
+
+    // Network address column types
 
-    "date":      "time.Time",
-    "time":      "time.Time",
-    "timestamp": "time.Time",
+    "inet": "netip.Prefix",
+    "cidr": "netip.Prefix",
 
     // Slice of bytes column types
 
@@ -331,21 +619,29 @@ func (g *Storage) columnNotNullToFieldTypMapping(columnTyp string) (string, bool
 
     // Slice of floats column types
 
-    "real[]":    "[]float64",
-    "float[]":   "[]float64",
-    "double[]":  "[]float64",
-    "decimal[]": "[]float64",
-    "numeric[]": "[]float64",
+    "real[]":   "[]float64",
+    "float[]":  "[]float64",
+    "double[]": "[]float64",
+
+    "decimal[]": "[]pgtype.Numeric",
+    "numeric[]": "[]pgtype.Numeric",
 
     // Slice of strings column types
 
     "text[]": "[]string",
+    "uuid[]": "[]string",
   }[columnTyp]
 
   // Try override type from pg type config
   if customTyp, ok2 := g.config.PgTypeConfig[columnTyp]; ok2 {
     return customTyp.GoType, true
   }
+  if enum, ok2 := g.enumsByTypes[columnTyp]; ok2 {
+    return enum.EnumName, true
+  }
+  if enum, ok2 := g.enumArrayByTypes(columnTyp); ok2 {
+    return fmt.Sprint(sliceTypPrefix, enum.EnumName), true
+  }
 
   return fieldTyp, ok1
 }
@@ -371,23 +667,39 @@ func (g *Storage) columnNullableTypToFieldTyp(columnTyp string) (string, bool) {
     "money",
     "real",
     "float",
-    "double",
+    "double":
+    fieldTyp = "zero.Float"
+
+  // Null values are not valid for types below
+
+  case
     "decimal",
     "numeric":
-    fieldTyp = "zero.Float"
+    fieldTyp = "pgtype.Numeric"
+
+  case "interval":
+    fieldTyp = "pgtype.Interval"
+
+  case
+    "inet",
+    "cidr":
+    fieldTyp = "netip.Prefix"
 
   case
     "varchar",
     "varying",
     "character",
     "uuid",
-    "text":
+    "text",
+    "citext",
+    "ltree":
     fieldTyp = "zero.String"
 
   case
     "date",
     "time",
-    "timestamp":
+    "timestamp",
+    "timestamptz":
     fieldTyp = "zero.Time"
 
   case
@@ -417,14 +729,19 @@ func (g *Storage) columnNullableTypToFieldTyp(columnTyp string) (string, bool) {
   case
     "real[]",
     "float[]",
-    "double[]",
+    "double[]":
+    fieldTyp = "[]float64"
+
+  case
     "decimal[]",
     "numeric[]":
-    fieldTyp = "[]float64"
+    fieldTyp = "[]pgtype.Numeric"
 
   // Slice of strings column types
 
-  case "text[]":
+  case
+    "text[]",
+    "uuid[]":
     fieldTyp = "[]string"
 
   default:
@@ -435,6 +752,13 @@ func (g *Storage) columnNullableTypToFieldTyp(columnTyp string) (string, bool) {
   if customTyp, ok2 := g.config.PgTypeConfig[columnTyp]; ok2 {
     return customTyp.GoZeroType, true
   }
+  if enum, ok2 := g.enumsByTypes[columnTyp]; ok2 {
+    return enum.NullEnumName, true
+  }
+  // Null values of slices are nil slices
+  if enum, ok2 := g.enumArrayByTypes(columnTyp); ok2 {
+    return fmt.Sprint(sliceTypPrefix, enum.EnumName), true
+  }
 
   return fieldTyp, ok1
 }
@@ -442,6 +766,11 @@ func (g *Storage) columnNullableTypToFieldTyp(columnTyp string) (string, bool) {
 func buildFieldTypeSuffix(fieldTyp string) string {
   var fieldTypSuffix string
 
+  // Null enum type contains enum typed field
+  if matchNullEnumTyp(fieldTyp) {
+    return "." + strings.TrimPrefix(fieldTyp, nullEnumTypPrefix)
+  }
+
   if matchZeroTyp(fieldTyp) {
     // zero.Int{} and zero.Float{}
     // Contains zero.Int{}.Int64 and zero.Float{}.Float64 fields
@@ -460,16 +789,74 @@ func buildFieldIfStmt(fieldName, fieldTyp string) string {
   if matchSliceTyp(fieldTyp) {
     fieldIfStmt = fmt.Sprintf(templates.StorageInputIfStmtWithLen, fieldName)
   }
-  if matchZeroTyp(fieldTyp) {
+  if matchZeroTyp(fieldTyp) || matchNullEnumTyp(fieldTyp) {
     fieldIfStmt = fmt.Sprintf(templates.StorageInputIfStmtWithPtr, fieldName)
   }
+  if matchPgtypeTyp(fieldTyp) {
+    fieldIfStmt = fmt.Sprintf(templates.StorageInputIfStmtWithValid, fieldName)
+  }
+  if matchNetipTyp(fieldTyp) {
+    fieldIfStmt = fmt.Sprintf(templates.StorageInputIfStmtWithIsValid, fieldName)
+  }
+  if matchPtrTyp(fieldTyp) {
+    fieldIfStmt = fmt.Sprintf(templates.StorageInputIfStmtWithNil, fieldName)
+  }
   return fieldIfStmt
 }
 
+// buildFieldNullStmt returns statement for null model field, empty for not null types
+func buildFieldNullStmt(fieldName, fieldTyp string) string {
+  var fieldNullStmt string
+
+  if matchSliceTyp(fieldTyp) {
+    fieldNullStmt = fmt.Sprintf(templates.StorageModelNullStmtWithNil, fieldName)
+  }
+  if matchZeroTyp(fieldTyp) || matchNullEnumTyp(fieldTyp) || matchPgtypeTyp(fieldTyp) {
+    fieldNullStmt = fmt.Sprintf(templates.StorageModelNullStmtWithValid, fieldName)
+  }
+  if matchNetipTyp(fieldTyp) {
+    fieldNullStmt = fmt.Sprintf(templates.StorageModelNullStmtWithIsValid, fieldName)
+  }
+  if matchPtrTyp(fieldTyp) {
+    fieldNullStmt = fmt.Sprintf(templates.StorageModelNullStmtWithNil, fieldName)
+  }
+  return fieldNullStmt
+}
+
 func matchSliceTyp(fieldTyp string) bool {
   return strings.HasPrefix(fieldTyp, sliceTypPrefix)
 }
 
+// matchComparableTyp reports if values of field type
+// can be compared by value and used as map keys
+func matchComparableTyp(fieldTyp string) bool {
+  return !matchSliceTyp(fieldTyp) && !matchPtrTyp(fieldTyp) && !matchPgtypeTyp(fieldTyp)
+}
+
+func matchPtrTyp(fieldTyp string) bool {
+  return strings.HasPrefix(fieldTyp, ptrTypPrefix)
+}
+
+// matchPgtypeTyp reports if field type is pgtype with Valid field like pgtype.Numeric
+func matchPgtypeTyp(fieldTyp string) bool {
+  return strings.HasPrefix(fieldTyp, pgtypePackageName+".")
+}
+
+func matchNetipTyp(fieldTyp string) bool {
+  return strings.HasPrefix(fieldTyp, netipPackageName+".")
+}
+
+func matchDecimalTyp(fieldTyp string) bool {
+  return fieldTyp == "pgtype.Numeric"
+}
+
+func matchAggregateTyp(fieldTyp string) bool {
+  if matchArrayTyp(fieldTyp) {
+    return false
+  }
+  return matchNumericTyp(fieldTyp) || matchZeroNumericTyp(fieldTyp) || matchDecimalTyp(fieldTyp)
+}
+
 func matchBoolTyp(fieldTyp string) bool {
   return fieldTyp == "bool"
 }
@@ -494,9 +881,31 @@ func matchZeroTyp(fieldTyp string) bool {
   return regexZeroPackageTyp.MatchString(fieldTyp)
 }
 
+// matchNullEnumTyp reports if field type is generated null enum,
+// custom types from pg type config contain package name
+func matchNullEnumTyp(fieldTyp string) bool {
+  return regexNullEnumTyp.MatchString(fieldTyp)
+}
+
+func (g *Storage) matchEnumTyp(fieldTyp string) bool {
+  return lo.ContainsBy(lo.Values(g.enumsByTypes), func(enum *enumDesc) bool {
+    return enum.EnumName == fieldTyp
+  })
+}
+
+// enumArrayByTypes returns enum of the array column type like mood[]
+func (g *Storage) enumArrayByTypes(columnTyp string) (*enumDesc, bool) {
+  if !strings.HasSuffix(columnTyp, "[]") {
+    return nil, false
+  }
+  enum, ok := g.enumsByTypes[strings.TrimSuffix(columnTyp, "[]")]
+  return enum, ok
+}
+
 var (
   regexNumericTyp     = regexp.MustCompile(`^(int(16|32|64)?)|float(32|64)$`)
   regexZeroPackageTyp = regexp.MustCompile(`^zero\.[A-Z][a-z]+$`)
+  regexNullEnumTyp    = regexp.MustCompile(`^Null[A-Z]\w*$`)
 )
 
 func buildNumericFilterName(fieldName, filterOperator string) string {
@@ -510,7 +919,7 @@ func buildNumericFilterType(fieldZeroTyp, fieldBuiltinTyp, filterOperator string
   return fieldZeroTyp
 }
 
-func buildNumericFilterIfStmt(filterName, filterOperator string) string {
+func buildNumericFilterIfStmt(filterName, filterTyp, filterOperator string) string {
   var filterIfStmt string
 
   switch filterOperator {
@@ -521,6 +930,10 @@ func buildNumericFilterIfStmt(filterName, filterOperator string) string {
     numericFilterOperatorLtOrEq,
     numericFilterOperatorEq:
     filterIfStmt = fmt.Sprintf(templates.StorageFilterIfStmtWithPtr, filterName)
+
+    if matchPgtypeTyp(filterTyp) {
+      filterIfStmt = fmt.Sprintf(templates.StorageFilterIfStmtWithValid, filterName)
+    }
   case
     numericFilterOperatorIn,
     numericFilterOperatorNotIn:
@@ -581,7 +994,14 @@ func buildStringFilterIfStmt(filterName, filterOperator string) string {
     stringFilterOperatorEq,
 
     stringFilterOperatorLike,
-    stringFilterOperatorNotLike:
+    stringFilterOperatorNotLike,
+    stringFilterOperatorILike,
+    stringFilterOperatorNotILike,
+
+    stringFilterOperatorStartsWith,
+    stringFilterOperatorIStartsWith,
+    stringFilterOperatorContains,
+    stringFilterOperatorIContains:
     filterIfStmt = fmt.Sprintf(templates.StorageFilterIfStmtWithPtr, filterName)
   case
     stringFilterOperatorIn,
@@ -598,13 +1018,98 @@ func buildStringFilterSqOperator(filterOperator string) string {
     stringFilterOperatorNotIn:   "NotEq",
     stringFilterOperatorLike:    "Like",
     stringFilterOperatorNotLike: "NotLike",
+
+    stringFilterOperatorILike:    "ILike",
+    stringFilterOperatorNotILike: "NotILike",
   }[filterOperator]
 }
 
+// buildStringFilterSqFunc returns function matching the escaped pattern
+func buildStringFilterSqFunc(filterOperator string) string {
+  return map[string]string{
+    stringFilterOperatorStartsWith:  "startsWithWhere",
+    stringFilterOperatorIStartsWith: "iStartsWithWhere",
+    stringFilterOperatorContains:    "containsWhere",
+    stringFilterOperatorIContains:   "iContainsWhere",
+  }[filterOperator]
+}
+
+// buildTimeRangeFilters returns filter by the time range of the field
+func buildTimeRangeFilters(filterOperators []string, fieldName string) []*fieldFilterDesc {
+  if !lo.Contains(filterOperators, timeFilterOperatorRange) {
+    return nil
+  }
+  filterName := fmt.Sprint(fieldName, timeFilterOperatorRange)
+
+  return []*fieldFilterDesc{
+    {
+      FilterName:   filterName,
+      FilterType:   "*TimeRange",
+      FilterIfStmt: fmt.Sprintf(templates.StorageFilterIfStmtWithNil, filterName),
+      FilterSqFunc: "timeRangeWhere",
+    },
+  }
+}
+
+// buildArrayFilters returns filters by the array values contained
+// in the field and values overlapping with the field
+func buildArrayFilters(filterOperators []string, fieldName, fieldBuiltinTyp string) []*fieldFilterDesc {
+  arrayFilters := make([]*fieldFilterDesc, 0, len(filterOperators))
+
+  for _, filterOperator := range filterOperators {
+    filterSqFunc := map[string]string{
+      arrayFilterOperatorContains: "arrayContainsWhere",
+      arrayFilterOperatorOverlaps: "arrayOverlapsWhere",
+    }[filterOperator]
+
+    // Operators of the other field types are skipped
+    if filterSqFunc == "" {
+      continue
+    }
+    filterName := fmt.Sprint(fieldName, filterOperator)
+
+    arrayFilters = append(arrayFilters, &fieldFilterDesc{
+      FilterName:   filterName,
+      FilterType:   fieldBuiltinTyp,
+      FilterIfStmt: fmt.Sprintf(templates.StorageFilterIfStmtWithLen, filterName),
+      FilterSqFunc: filterSqFunc,
+    })
+  }
+  return arrayFilters
+}
+
+// buildNullFilters returns filter by IS NULL for true value
+// and IS NOT NULL for false value of the nullable field
+func buildNullFilters(filterOperators []string, fieldName string) []*fieldFilterDesc {
+  if !lo.Contains(filterOperators, nullFilterOperatorIsNull) {
+    return nil
+  }
+  filterName := fmt.Sprint(fieldName, nullFilterOperatorIsNull)
+  filterTyp := "zero.Bool"
+
+  return []*fieldFilterDesc{
+    {
+      FilterName:       filterName,
+      FilterType:       filterTyp,
+      FilterIfStmt:     fmt.Sprintf(templates.StorageFilterIfStmtWithPtr, filterName),
+      FilterTypeSuffix: buildFieldTypeSuffix(filterTyp),
+      FilterSqFunc:     "isNullWhere",
+    },
+  }
+}
+
+// matchArrayTyp reports if field type is slice of the array column
+func matchArrayTyp(fieldTyp string) bool {
+  return matchSliceTyp(fieldTyp) && fieldTyp != "[]byte"
+}
+
 const (
-  fieldBadgePk         = "pk"
-  sliceTypPrefix       = "[]"
-  zeroTypPackagePrefix = "zero"
+  fieldBadgePk            = "pk"
+  nullEnumTypPrefix       = "Null"
+  sliceTypPrefix          = "[]"
+  ptrTypPrefix            = "*"
+  zeroTypPackagePrefix    = "zero"
+  readOnlyColumnTypPrefix = "ReadOnly"
 )
 
 const (
@@ -612,11 +1117,18 @@ const (
 )
 
 const (
-  stringFilterOperatorIn      = "In"
-  stringFilterOperatorNotIn   = "NotIn"
-  stringFilterOperatorEq      = "Eq"
-  stringFilterOperatorLike    = "Like"
-  stringFilterOperatorNotLike = "NotLike"
+  stringFilterOperatorIn       = "In"
+  stringFilterOperatorNotIn    = "NotIn"
+  stringFilterOperatorEq       = "Eq"
+  stringFilterOperatorLike     = "Like"
+  stringFilterOperatorNotLike  = "NotLike"
+  stringFilterOperatorILike    = "ILike"
+  stringFilterOperatorNotILike = "NotILike"
+
+  stringFilterOperatorStartsWith  = "StartsWith"
+  stringFilterOperatorIStartsWith = "IStartsWith"
+  stringFilterOperatorContains    = "Contains"
+  stringFilterOperatorIContains   = "IContains"
 )
 
 var modelStringFilterOperators = []string{
@@ -628,6 +1140,19 @@ var modelsStringFilterOperators = []string{
   stringFilterOperatorNotIn,
   stringFilterOperatorLike,
   stringFilterOperatorNotLike,
+  stringFilterOperatorILike,
+  stringFilterOperatorNotILike,
+  stringFilterOperatorStartsWith,
+  stringFilterOperatorIStartsWith,
+  stringFilterOperatorContains,
+  stringFilterOperatorIContains,
+  nullFilterOperatorIsNull,
+}
+
+var modelsEnumFilterOperators = []string{
+  stringFilterOperatorIn,
+  stringFilterOperatorNotIn,
+  nullFilterOperatorIsNull,
 }
 
 const (
@@ -651,13 +1176,40 @@ var modelsNumericFilterOperators = []string{
   numericFilterOperatorLtOrEq,
   numericFilterOperatorIn,
   numericFilterOperatorNotIn,
+  nullFilterOperatorIsNull,
+}
+
+const (
+  timeFilterOperatorRange  = "Range"
+  nullFilterOperatorIsNull = "IsNull"
+
+  arrayFilterOperatorContains = "Contains"
+  arrayFilterOperatorOverlaps = "Overlaps"
+)
+
+var modelsArrayFilterOperators = []string{
+  arrayFilterOperatorContains,
+  arrayFilterOperatorOverlaps,
+  nullFilterOperatorIsNull,
+}
+
+var modelsOtherFilterOperators = []string{
+  nullFilterOperatorIsNull,
 }
 
+var modelsTimeFilterOperators = append([]string{
+  timeFilterOperatorRange,
+}, modelsNumericFilterOperators...)
+
 type filterFieldTyp int
 
 const (
   filterFieldTypString  filterFieldTyp = 0
   filterFieldTypNumeric filterFieldTyp = 1
+  filterFieldTypEnum    filterFieldTyp = 2
+  filterFieldTypTime    filterFieldTyp = 3
+  filterFieldTypArray   filterFieldTyp = 4
+  filterFieldTypOther   filterFieldTyp = 5
 )
 
 func (g *Storage) buildFilterOperators(tableName, columnName string, filterField filterFieldTyp) []string {
@@ -675,8 +1227,15 @@ func (g *Storage) buildFilterOperators(tableName, columnName string, filterField
   switch filterField {
   case filterFieldTypString:
     filterOps = filtersConfig.String
-  case filterFieldTypNumeric:
+  case filterFieldTypNumeric, filterFieldTypTime:
     filterOps = filtersConfig.Numeric
+  case filterFieldTypArray:
+    filterOps = filtersConfig.Array
+  case filterFieldTypEnum:
+    // Enums compared with string filters except patterns
+    filterOps = lo.Filter(filtersConfig.String, func(filterOp string, _ int) bool {
+      return lo.Contains(modelsEnumFilterOperators, filterOp)
+    })
   }
   if len(filterOps) != 0 {
     return filterOps
@@ -691,6 +1250,14 @@ func (g *Storage) buildFilterOperators(tableName, columnName string, filterField
     filterOps = modelsStringFilterOperators
   case filterFieldTypNumeric:
     filterOps = modelsNumericFilterOperators
+  case filterFieldTypEnum:
+    filterOps = modelsEnumFilterOperators
+  case filterFieldTypTime:
+    filterOps = modelsTimeFilterOperators
+  case filterFieldTypArray:
+    filterOps = modelsArrayFilterOperators
+  case filterFieldTypOther:
+    filterOps = modelsOtherFilterOperators
   }
   return filterOps
 }
@@ -755,6 +1322,7 @@ func buildFilterFieldPackages(filterTyp string) ([]*goPackageDesc, bool) {
 func buildCrossFilePackages(goModuleName, fileName string) []*goPackageDesc {
   crossFileNames := map[string][]string{
     modelMethodsFileName: {modelsFileName},
+    enumsFileName:        {modelsFileName},
   }[fileName]
 
   var (
@@ -803,9 +1371,12 @@ const (
   buildersFileName     = "builders"
   storageFileName      = "storage"
   optionsFileName      = "options"
+  queryFileName        = "query"
   modelsFileName       = "models"
   modelOptionsFileName = "model_options"
   modelMethodsFileName = "model_methods"
+  enumsFileName        = "enums"
+  modelEnumsFileName   = "model_enums"
 )
 
 var importPackagesByFiles = map[string][]string{
@@ -820,6 +1391,17 @@ var importPackagesByFiles = map[string][]string{
   },
   optionsFileName: {
     fmtPackageName,
+    base64PackageName,
+    jsonPackageName,
+    stringsPackageName,
+    squirrelPackageName,
+    zeroPackageName,
+  },
+  queryFileName: {
+    contextPackageName,
+    fmtPackageName,
+    squirrelPackageName,
+    pgExecutorPackageName,
   },
   modelOptionsFileName: {
     fmtPackageName,
@@ -829,6 +1411,9 @@ var importPackagesByFiles = map[string][]string{
   modelMethodsFileName: {
     contextPackageName,
     fmtPackageName,
+    jsonPackageName,
+    timePackageName,
+    zeroPackageName,
     squirrelPackageName,
     pgExecutorPackageName,
     pgBuilderPackageName,
@@ -837,6 +1422,12 @@ var importPackagesByFiles = map[string][]string{
     timePackageName,
     zeroPackageName,
   },
+  enumsFileName: {},
+  modelEnumsFileName: {
+    fmtPackageName,
+    jsonPackageName,
+    driverPackageName,
+  },
   constsFileName: {},
 }
 
@@ -846,9 +1437,15 @@ const (
   zeroPackageName    = "zero"
   timePackageName    = "time"
   errorsPackageName  = "errors"
+  jsonPackageName    = "json"
+  base64PackageName  = "base64"
+  stringsPackageName = "strings"
 
   logrusPackageName      = "logrus"
   databaseSqlPackageName = "sql"
+  driverPackageName      = "driver"
+  netipPackageName       = "netip"
+  pgtypePackageName      = "pgtype"
   squirrelPackageName    = "squirrel"
 
   pgPgxPackageName      = "pg-pgx"
@@ -889,11 +1486,41 @@ var importPackagesByNames = map[string]*goPackageDesc{
     ImportLine: "database/sql",
     IsBuiltin:  true,
   },
+  driverPackageName: {
+    CustomName: "database/sql/driver",
+    ImportLine: "database/sql/driver",
+    IsBuiltin:  true,
+  },
+  netipPackageName: {
+    CustomName: "net/netip",
+    ImportLine: "net/netip",
+    IsBuiltin:  true,
+  },
+  pgtypePackageName: {
+    CustomName: "jackc/pgtype",
+    ImportLine: "github.com/jackc/pgx/v5/pgtype",
+    IsInstall:  true,
+  },
   errorsPackageName: {
     CustomName: "go/errors",
     ImportLine: "errors",
     IsBuiltin:  true,
   },
+  jsonPackageName: {
+    CustomName: "encoding/json",
+    ImportLine: "encoding/json",
+    IsBuiltin:  true,
+  },
+  base64PackageName: {
+    CustomName: "encoding/base64",
+    ImportLine: "encoding/base64",
+    IsBuiltin:  true,
+  },
+  stringsPackageName: {
+    CustomName: "go/strings",
+    ImportLine: "strings",
+    IsBuiltin:  true,
+  },
   logrusPackageName: {
     CustomName:  "sirupsen/logrus",
     ImportLine:  "github.com/sirupsen/logrus",
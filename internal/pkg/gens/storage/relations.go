@@ -0,0 +1,186 @@
+package storage
+
+import (
+  "fmt"
+  "strings"
+
+  "github.com/samber/lo"
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/internal/pkg/pgdump"
+)
+
+// Relation of the child table to the parent table by the foreign key,
+// exposed for generators reusing storage models like dataloaders
+type Relation struct {
+  Name string
+
+  ChildTable   string
+  ChildModel   string
+  ChildColumns []string
+
+  ParentTable   string
+  ParentModel   string
+  ParentColumns []string
+
+  // Storage method listing child models by parent keys,
+  // empty for composite foreign keys
+  LoaderName string
+
+  // Parent model field with child models loaded by list methods,
+  // empty if relation cannot be loaded
+  EagerFieldName string
+}
+
+type relationDesc struct {
+  Relation *Relation
+
+  ChildModelName  string
+  ParentModelName string
+  LoaderName      string
+  EagerFieldName  string
+
+  ChildField  *fieldDesc
+  ParentField *fieldDesc
+
+  // Parent key of the child model and child key of the parent model
+  ChildParentKey string
+  ParentChildKey string
+}
+
+// Relations returns foreign key relations between generated models
+func (g *Storage) Relations() ([]*Relation, error) {
+  if g.schemaDesc == nil {
+    if err := g.loadSchemaDesc(); err != nil {
+      return nil, fmt.Errorf("loadSchemaDesc: %w", err)
+    }
+  }
+  return g.schemaDesc.Relations, nil
+}
+
+// buildRelations attaches relations to the child and parent models,
+// relations with skipped tables are not built
+func buildRelations(tables []*pgdump.DumpTable, models map[string]*modelDesc) ([]*Relation, error) {
+  var relations []*Relation
+
+  for _, table := range tables {
+    child, ok := models[table.RawName]
+    if !ok {
+      continue
+    }
+    for _, foreignKey := range table.ForeignKeys {
+      parent, ok := models[foreignKey.RefRawName]
+      if !ok {
+        log.Debugf("storage: relation '%s' skipped: table '%s' not generated", foreignKey.Name, foreignKey.RefRawName)
+        continue
+      }
+      relation, err := buildRelation(table, foreignKey, child, parent)
+      if err != nil {
+        return nil, fmt.Errorf("buildRelation: %w", err)
+      }
+      relations = append(relations, relation.Relation)
+
+      if relation.LoaderName != "" {
+        child.ModelLoaders = append(child.ModelLoaders, relation)
+      }
+      if relation.EagerFieldName != "" {
+        parent.ModelEagerRelations = append(parent.ModelEagerRelations, relation)
+      }
+    }
+  }
+  return relations, nil
+}
+
+func buildRelation(table *pgdump.DumpTable, foreignKey *pgdump.DumpForeignKey, child, parent *modelDesc) (*relationDesc, error) {
+  childFields := make([]*fieldDesc, 0, len(foreignKey.Columns))
+  parentFields := make([]*fieldDesc, 0, len(foreignKey.RefColumns))
+
+  for idx, column := range foreignKey.Columns {
+    childField, ok := findField(child.ModelFields, column)
+    if !ok {
+      return nil, fmt.Errorf("foreign key %s: column not found: %s", foreignKey.Name, column)
+    }
+    parentField, ok := findField(parent.ModelFields, foreignKey.RefColumns[idx])
+    if !ok {
+      return nil, fmt.Errorf("foreign key %s: referenced column not found: %s", foreignKey.Name, foreignKey.RefColumns[idx])
+    }
+    childFields = append(childFields, childField)
+    parentFields = append(parentFields, parentField)
+  }
+  relationName := parent.ModelName
+
+  // Field names used for relations with the same parent and self relations
+  if foreignKey.RefRawName == table.RawName || lo.CountBy(table.ForeignKeys, func(key *pgdump.DumpForeignKey) bool {
+    return key.RefRawName == foreignKey.RefRawName
+  }) > 1 {
+    relationName = buildRelationName(childFields)
+  }
+  desc := &relationDesc{
+    Relation: &Relation{
+      Name:          foreignKey.Name,
+      ChildTable:    child.SqlTableName,
+      ChildModel:    child.ModelName,
+      ChildColumns:  foreignKey.Columns,
+      ParentTable:   parent.SqlTableName,
+      ParentModel:   parent.ModelName,
+      ParentColumns: foreignKey.RefColumns,
+    },
+    ChildModelName:  child.ModelName,
+    ParentModelName: parent.ModelName,
+  }
+  // Loaders for composite foreign keys are not supported
+  if len(childFields) != 1 {
+    return desc, nil
+  }
+  childField, parentField := childFields[0], parentFields[0]
+
+  desc.ChildField = childField
+  desc.ParentField = parentField
+  desc.LoaderName = fmt.Sprintf("List%sBy%sIDs", child.ModelName, relationName)
+
+  if canLoadRelation(childField, parentField) {
+    desc.EagerFieldName = child.ModelName + "s"
+
+    if relationName != parent.ModelName {
+      desc.EagerFieldName += "By" + relationName
+    }
+    desc.ChildParentKey = buildRelationKey(parentField.FieldType, childField)
+    desc.ParentChildKey = buildRelationKey(childField.FieldBuiltinType, parentField)
+  }
+  desc.Relation.LoaderName = desc.LoaderName
+  desc.Relation.EagerFieldName = desc.EagerFieldName
+
+  return desc, nil
+}
+
+func buildRelationName(fields []*fieldDesc) string {
+  var relationName string
+
+  for _, field := range fields {
+    fieldName := strings.TrimSuffix(field.FieldName, "Id")
+
+    if fieldName == "" {
+      fieldName = field.FieldName
+    }
+    relationName += fieldName
+  }
+  return relationName
+}
+
+// canLoadRelation reports if models grouped by keys converted to parent field type
+func canLoadRelation(childField, parentField *fieldDesc) bool {
+  if !parentField.NotNullField || !matchComparableTyp(childField.FieldBuiltinType) || !matchComparableTyp(parentField.FieldType) {
+    return false
+  }
+  if childField.FieldBuiltinType == parentField.FieldType {
+    return true
+  }
+  return matchNumericTyp(childField.FieldBuiltinType) && matchNumericTyp(parentField.FieldType)
+}
+
+// buildRelationKey returns model field value converted to key type
+func buildRelationKey(keyTyp string, field *fieldDesc) string {
+  if field.FieldTypeSuffix != "" {
+    return fmt.Sprintf("%s(model.%s%s)", keyTyp, field.FieldName, field.FieldTypeSuffix)
+  }
+  return fmt.Sprintf("%s(model.%s)", keyTyp, field.FieldName)
+}
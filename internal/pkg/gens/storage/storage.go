@@ -20,6 +20,7 @@ type Storage struct {
   dumpSQL *pgdump.DumpSQL
 
   schemaDesc   *schemaDesc
+  enumsByTypes map[string]*enumDesc
   workDirPath  string
   goModuleName string
 }
@@ -241,6 +242,28 @@ var storageCommonTemplates = []*storageTemplate{
       return "storage.options.go"
     },
   },
+  {
+    templateName:     "query",
+    compiledTemplate: templates.StorageQuery,
+    fileNameBuild: func(modelName string) string {
+      return "storage.query.go"
+    },
+  },
+  {
+    templateName:     "enums",
+    compiledTemplate: templates.StorageEnums,
+    fileNameBuild: func(modelName string) string {
+      return "storage.enums.go"
+    },
+  },
+  {
+    templateName:     "model_enums",
+    compiledTemplate: templates.StorageModelEnums,
+    filePathParts:    []string{"models"},
+    fileNameBuild: func(modelName string) string {
+      return "enums.go"
+    },
+  },
   {
     templateName:     "consts",
     compiledTemplate: templates.StorageConsts,
@@ -7,6 +7,7 @@ import (
   "strings"
   "unicode"
 
+  "github.com/samber/lo"
   log "github.com/sirupsen/logrus"
   "github.com/ushakovn/boiler/internal/pkg/filer"
   "github.com/ushakovn/boiler/internal/pkg/stack"
@@ -14,15 +15,43 @@ import (
 
 type DumpSQL struct {
   Tables    *stack.Stack[*DumpTable]
+  Enums     []*DumpEnum
   tempStack *stack.Stack[string]
   option    DumpOption
 }
 
 type DumpTable struct {
+  RawName     string
+  Name        string
+  Schema      string
+  Columns     stack.Stack[*DumpColumn]
+  PrimaryKey  []string
+  UniqueKeys  []*DumpUniqueKey
+  ForeignKeys []*DumpForeignKey
+}
+
+// DumpUniqueKey columns of the UNIQUE constraint or unique index
+type DumpUniqueKey struct {
+  Name    string
+  Columns []string
+}
+
+// DumpForeignKey columns of the FOREIGN KEY constraint and referenced
+// columns of the table with RefRawName matching DumpTable.RawName
+type DumpForeignKey struct {
+  Name       string
+  Columns    []string
+  RefRawName string
+  RefColumns []string
+}
+
+// DumpEnum labels of the CREATE TYPE ... AS ENUM statement in declaration order,
+// labels are case-sensitive unlike other dump tokens
+type DumpEnum struct {
   RawName string
   Name    string
   Schema  string
-  Columns stack.Stack[*DumpColumn]
+  Values  []string
 }
 
 type DumpColumn struct {
@@ -39,8 +68,15 @@ func (o DumpOption) Do() (*DumpSQL, error) {
   if err := o.Validate(); err != nil {
     return nil, fmt.Errorf("pg dump option invalid: %w", err)
   }
+  // Enums extracted before lowercasing pg dump
+  enums, pgDump, err := extractEnums(o.pgDump)
+  if err != nil {
+    return nil, fmt.Errorf("extractEnums: %w", err)
+  }
+  o.enums = enums
+
   // Sanitize pg dump before scanning tokens
-  o.pgDump = sanitizePgDump(o.pgDump)
+  o.pgDump = sanitizePgDump(pgDump)
 
   tokens, err := scanSchemaSQLTokens(o.pgDump)
   if err != nil {
@@ -68,19 +104,13 @@ func (o DumpOption) Do() (*DumpSQL, error) {
 func sanitizeDumpSQL(dump *DumpSQL) *DumpSQL {
   sanitized := &DumpSQL{
     Tables: stack.NewStack[*DumpTable](),
+    Enums:  dump.Enums,
   }
   for _, table := range dump.Tables.Elems() {
-    var ok bool
-
-    if _, ok = systemTablesNames[table.Name]; ok {
+    if _, ok := systemTablesNames[table.Name]; ok {
       continue
     }
-    for _, column := range table.Columns.Elems() {
-      if ok = column.IsPrimaryKey; ok {
-        break
-      }
-    }
-    if !ok {
+    if len(table.PrimaryKey) == 0 {
       log.Warnf("pg_dump: table '%s' skipped: does not contain a primary key", table.Name)
       // Skip tables without primary key
       continue
@@ -90,6 +120,27 @@ func sanitizeDumpSQL(dump *DumpSQL) *DumpSQL {
   return sanitized
 }
 
+func (d *DumpSQL) tableIndex(rawName string) (int, bool) {
+  for idx, table := range d.Tables.Elems() {
+    if table.RawName == rawName {
+      return idx, true
+    }
+  }
+  return 0, false
+}
+
+// missingColumn returns first of the columns not found in the table
+func (t *DumpTable) missingColumn(columns []string) (string, bool) {
+  for _, columnName := range columns {
+    if !lo.ContainsBy(t.Columns.Elems(), func(column *DumpColumn) bool {
+      return column.Name == columnName
+    }) {
+      return columnName, true
+    }
+  }
+  return "", false
+}
+
 func scanSchemaSQLTokens(pgDump []byte) ([]string, error) {
   var tokens []string
 
@@ -145,6 +196,7 @@ func sanitizePgDump(pgDump []byte) []byte {
 
   s = sanitizeTimestamps(s)
   s = sanitizeNumerics(s)
+  s = sanitizeTimePrecisions(s)
   s = sanitizeConstraints(s)
 
   return []byte(s)
@@ -162,8 +214,11 @@ func sanitizeConstraints(pgDump string) string {
   matchCns := regexSqlConstraint.FindAllString(pgDump, -1)
 
   for _, matchCn := range matchCns {
-    // NOT SANITIZE pk constraint where pk NOT a composite
-    if regexSqlPkConstraint.MatchString(matchCn) && !regexSqlCompositePk.MatchString(matchCn) {
+    // NOT SANITIZE pk, unique and foreign key constraints from ALTER TABLE statement
+    if (regexSqlPkConstraint.MatchString(matchCn) ||
+      regexSqlUniqueConstraint.MatchString(matchCn) ||
+      regexSqlFkConstraint.MatchString(matchCn)) &&
+      strings.Contains(pgDump, "add "+matchCn) {
       continue
     }
     pgDump = strings.Replace(pgDump, matchCn, "", 1)
@@ -171,14 +226,23 @@ func sanitizeConstraints(pgDump string) string {
   return pgDump
 }
 
+// sanitizeNumerics trims precision and scale of the numeric types
 func sanitizeNumerics(pgDump string) string {
-  return regexSqlNumeric.ReplaceAllLiteralString(pgDump, "double")
+  pgDump = strings.ReplaceAll(pgDump, "double precision", "double")
+  return regexSqlNumeric.ReplaceAllString(pgDump, "$1")
+}
+
+// sanitizeTimePrecisions trims fractional seconds precision of the time, timestamp and interval types
+func sanitizeTimePrecisions(pgDump string) string {
+  return regexSqlTimePrecision.ReplaceAllString(pgDump, "$1")
 }
 
 var (
-  regexSqlTimestamp    = regexp.MustCompile(`timezone\(.+\)|now\(.*\)`)
-  regexSqlConstraint   = regexp.MustCompile(`constraint\s.*`)
-  regexSqlPkConstraint = regexp.MustCompile(`constraint\s.*_pkey\s.*`)
-  regexSqlCompositePk  = regexp.MustCompile(`\(\w+,.*\w+\)`)
-  regexSqlNumeric      = regexp.MustCompile(`(decimal|numeric)\s*\(.*\)`)
+  regexSqlTimestamp        = regexp.MustCompile(`timezone\(.+\)|now\(.*\)`)
+  regexSqlConstraint       = regexp.MustCompile(`constraint\s.*`)
+  regexSqlPkConstraint     = regexp.MustCompile(`constraint\s.*_pkey\s.*`)
+  regexSqlUniqueConstraint = regexp.MustCompile(`constraint\s+\S+\s+unique\s.*`)
+  regexSqlFkConstraint     = regexp.MustCompile(`constraint\s+\S+\s+foreign\s+key\s.*`)
+  regexSqlNumeric          = regexp.MustCompile(`(decimal|numeric)\s*\(\s*\d+\s*(,\s*-?\d+\s*)?\)`)
+  regexSqlTimePrecision    = regexp.MustCompile(`\b(timestamptz|timestamp|timetz|time|interval)\s*\(\s*\d+\s*\)`)
 )
@@ -0,0 +1,206 @@
+package pgdump
+
+import (
+  "testing"
+
+  "github.com/go-playground/assert/v2"
+)
+
+const testCustomerTable = `
+CREATE TABLE public.customer (
+    id bigint NOT NULL,
+    tenant_id integer NOT NULL,
+    login character varying(64) NOT NULL,
+    email text,
+    name text NOT NULL
+);
+`
+
+func Test_DumpSQLKeys(t *testing.T) {
+  tests := []struct {
+    name        string
+    pgDump      string
+    table       string
+    primaryKey  []string
+    uniqueKeys  []*DumpUniqueKey
+    foreignKeys []*DumpForeignKey
+  }{
+    {
+      name: "primary key",
+      pgDump: testCustomerTable + `
+ALTER TABLE ONLY public.customer
+    ADD CONSTRAINT customer_pkey PRIMARY KEY (id);
+`,
+      table:      "public.customer",
+      primaryKey: []string{"id"},
+    },
+    {
+      name: "composite primary key",
+      pgDump: `
+CREATE TABLE public.tenant_user (
+    user_id bigint NOT NULL,
+    tenant_id bigint NOT NULL,
+    role text
+);
+
+ALTER TABLE ONLY public.tenant_user
+    ADD CONSTRAINT tenant_user_pkey PRIMARY KEY (tenant_id, user_id);
+`,
+      table:      "public.tenant_user",
+      primaryKey: []string{"tenant_id", "user_id"},
+    },
+    {
+      name: "unique constraints and indexes",
+      pgDump: testCustomerTable + `
+ALTER TABLE ONLY public.customer
+    ADD CONSTRAINT customer_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.customer
+    ADD CONSTRAINT customer_login_key UNIQUE (login);
+
+ALTER TABLE ONLY public.customer
+    ADD CONSTRAINT customer_email_key UNIQUE (email) DEFERRABLE;
+
+CREATE UNIQUE INDEX customer_tenant_login_idx ON public.customer USING btree (tenant_id, login);
+
+CREATE UNIQUE INDEX customer_lower_email_idx ON public.customer USING btree (lower(email));
+
+CREATE UNIQUE INDEX customer_name_idx ON public.customer USING btree (name) WHERE (tenant_id > 0);
+
+CREATE INDEX customer_name_not_unique_idx ON public.customer USING btree (name);
+`,
+      table:      "public.customer",
+      primaryKey: []string{"id"},
+      // Deferrable, expression and partial keys are skipped
+      uniqueKeys: []*DumpUniqueKey{
+        {
+          Name:    "customer_login_key",
+          Columns: []string{"login"},
+        },
+        {
+          Name:    "customer_tenant_login_idx",
+          Columns: []string{"tenant_id", "login"},
+        },
+      },
+    },
+    {
+      name: "foreign keys",
+      pgDump: testCustomerTable + `
+CREATE TABLE public.customer_order (
+    id bigint NOT NULL,
+    customer_id bigint NOT NULL,
+    referrer_id bigint
+);
+
+ALTER TABLE ONLY public.customer
+    ADD CONSTRAINT customer_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.customer_order
+    ADD CONSTRAINT customer_order_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.customer_order
+    ADD CONSTRAINT customer_order_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES public.customer(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.customer_order
+    ADD CONSTRAINT customer_order_referrer_id_fkey FOREIGN KEY (referrer_id) REFERENCES public.customer(id) DEFERRABLE INITIALLY DEFERRED;
+`,
+      table:      "public.customer_order",
+      primaryKey: []string{"id"},
+      foreignKeys: []*DumpForeignKey{
+        {
+          Name:       "customer_order_customer_id_fkey",
+          Columns:    []string{"customer_id"},
+          RefRawName: "public.customer",
+          RefColumns: []string{"id"},
+        },
+        {
+          Name:       "customer_order_referrer_id_fkey",
+          Columns:    []string{"referrer_id"},
+          RefRawName: "public.customer",
+          RefColumns: []string{"id"},
+        },
+      },
+    },
+  }
+  for _, test := range tests {
+    t.Run(test.name, func(t *testing.T) {
+      dump, err := DumpOption{pgDump: []byte(test.pgDump)}.Do()
+      assert.Equal(t, nil, err)
+
+      idx, ok := dump.tableIndex(test.table)
+      assert.Equal(t, true, ok)
+
+      table := dump.Tables.Elems()[idx]
+
+      assert.Equal(t, test.primaryKey, table.PrimaryKey)
+      assert.Equal(t, test.uniqueKeys, table.UniqueKeys)
+      assert.Equal(t, test.foreignKeys, table.ForeignKeys)
+    })
+  }
+}
+
+func Test_DumpSQLSkipsTableWithoutPrimaryKey(t *testing.T) {
+  dump, err := DumpOption{pgDump: []byte(testCustomerTable)}.Do()
+  assert.Equal(t, nil, err)
+
+  _, ok := dump.tableIndex("public.customer")
+  assert.Equal(t, false, ok)
+}
+
+func Test_DumpSQLEnums(t *testing.T) {
+  pgDump := `
+CREATE TYPE public.order_status AS ENUM (
+    'new',
+    'In Progress',
+    'it''s done'
+);
+
+CREATE TYPE mood AS ENUM ();
+
+CREATE TABLE public.shipment (
+    id bigint NOT NULL,
+    status public.order_status DEFAULT 'new'::public.order_status NOT NULL,
+    history public.order_status[],
+    mood mood
+);
+
+ALTER TABLE ONLY public.shipment
+    ADD CONSTRAINT shipment_pkey PRIMARY KEY (id);
+`
+  dump, err := DumpOption{pgDump: []byte(pgDump)}.Do()
+  assert.Equal(t, nil, err)
+
+  // Labels keep case and escaped quotes
+  assert.Equal(t, []*DumpEnum{
+    {
+      RawName: "public.order_status",
+      Name:    "order_status",
+      Schema:  "public",
+      Values:  []string{"new", "In Progress", "it's done"},
+    },
+    {
+      RawName: "mood",
+      Name:    "mood",
+    },
+  }, dump.Enums)
+
+  idx, ok := dump.tableIndex("public.shipment")
+  assert.Equal(t, true, ok)
+
+  columns := dump.Tables.Elems()[idx].Columns.Elems()
+  assert.Equal(t, 4, len(columns))
+
+  assert.Equal(t, "order_status", columns[1].Typ)
+  assert.Equal(t, true, columns[1].WithDefault)
+  assert.Equal(t, "order_status[]", columns[2].Typ)
+  assert.Equal(t, "mood", columns[3].Typ)
+
+  // Unknown type is not parsed as enum
+  _, err = DumpOption{pgDump: []byte(`
+CREATE TABLE public.shipment (
+    id bigint NOT NULL,
+    status public.order_status
+);
+`)}.Do()
+  assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,115 @@
+package pgdump
+
+import (
+  "fmt"
+  "regexp"
+  "strings"
+  "unicode"
+)
+
+// extractEnums returns enums from CREATE TYPE ... AS ENUM statements
+// and pg dump without these statements
+func extractEnums(pgDump []byte) ([]*DumpEnum, []byte, error) {
+  var (
+    enums     []*DumpEnum
+    sanitized strings.Builder
+  )
+  s := string(pgDump)
+
+  for {
+    loc := regexSqlCreateEnum.FindStringSubmatchIndex(s)
+    if loc == nil {
+      break
+    }
+    rawName := strings.ToLower(strings.ReplaceAll(s[loc[2]:loc[3]], `"`, ""))
+
+    values, end, err := scanEnumValues(s[loc[1]:])
+    if err != nil {
+      return nil, nil, fmt.Errorf("enum %s: %w", rawName, err)
+    }
+    enum := &DumpEnum{
+      RawName: rawName,
+      Name:    rawName,
+      Values:  values,
+    }
+    if schema, name, ok := strings.Cut(rawName, "."); ok {
+      enum.Schema = schema
+      enum.Name = name
+    }
+    enums = append(enums, enum)
+
+    sanitized.WriteString(s[:loc[0]])
+    s = s[loc[1]+end:]
+  }
+  sanitized.WriteString(s)
+
+  return enums, []byte(sanitized.String()), nil
+}
+
+// scanEnumValues returns quoted labels of the enum until closing bracket
+// and length of the scanned statement part including semicolon
+func scanEnumValues(s string) ([]string, int, error) {
+  var (
+    values []string
+    idx    int
+  )
+  skipSpaces := func() {
+    for idx < len(s) && unicode.IsSpace(rune(s[idx])) {
+      idx++
+    }
+  }
+  for {
+    skipSpaces()
+
+    if idx >= len(s) {
+      return nil, 0, fmt.Errorf("closing bracket not found")
+    }
+    if s[idx] == ')' && len(values) == 0 {
+      break
+    }
+    if s[idx] != '\'' {
+      return nil, 0, fmt.Errorf("%w: %c", errUnexpectedToken, s[idx])
+    }
+    var value strings.Builder
+
+    for idx++; ; idx++ {
+      if idx >= len(s) {
+        return nil, 0, fmt.Errorf("closing quote not found")
+      }
+      if s[idx] != '\'' {
+        value.WriteByte(s[idx])
+        continue
+      }
+      // Escaped quote inside label
+      if idx+1 < len(s) && s[idx+1] == '\'' {
+        value.WriteByte('\'')
+        idx++
+        continue
+      }
+      idx++
+      break
+    }
+    values = append(values, value.String())
+
+    skipSpaces()
+
+    if idx < len(s) && s[idx] == ',' {
+      idx++
+      continue
+    }
+    if idx < len(s) && s[idx] == ')' {
+      break
+    }
+    return nil, 0, fmt.Errorf("closing bracket not found")
+  }
+  // Skip closing bracket
+  idx++
+  skipSpaces()
+
+  if idx < len(s) && s[idx] == ';' {
+    idx++
+  }
+  return values, idx, nil
+}
+
+var regexSqlCreateEnum = regexp.MustCompile(`(?i)create\s+type\s+([\w."]+)\s+as\s+enum\s*\(`)
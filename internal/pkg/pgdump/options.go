@@ -14,6 +14,7 @@ import (
 type DumpOption struct {
   pgDump      []byte
   customTypes map[string]struct{}
+  enums       []*DumpEnum
 }
 
 func NewDumpOption() DumpOption {
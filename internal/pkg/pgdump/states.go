@@ -6,6 +6,7 @@ import (
   "regexp"
   "strings"
 
+  "github.com/samber/lo"
   log "github.com/sirupsen/logrus"
   "github.com/ushakovn/boiler/internal/pkg/stack"
   "github.com/ushakovn/boiler/internal/pkg/stringer"
@@ -18,6 +19,7 @@ type state interface {
 func newTerminateState(option DumpOption) state {
   return &terminate{dump: &DumpSQL{
     Tables:    stack.NewStack[*DumpTable](),
+    Enums:     option.enums,
     tempStack: stack.NewStack[string](),
     option:    option,
   }}
@@ -83,11 +85,329 @@ func (t *create) next(token string) (state, error) {
   switch token {
   case "table":
     return &table{dump: t.dump}, nil
+  case "unique":
+    return &createUnique{dump: t.dump}, nil
   default:
     return &terminate{dump: t.dump}, nil
   }
 }
 
+type createUnique struct {
+  dump *DumpSQL
+}
+
+func (t *createUnique) next(token string) (state, error) {
+  switch token {
+  case "index":
+    return &uniqueIndex{dump: t.dump}, nil
+  default:
+    return &terminate{dump: t.dump}, nil
+  }
+}
+
+type uniqueIndex struct {
+  dump *DumpSQL
+}
+
+func (t *uniqueIndex) next(token string) (state, error) {
+  token = trimEscapeQuotes(token)
+
+  switch {
+  case matchConstraintName(token):
+    return &uniqueIndexName{dump: t.dump, name: token}, nil
+  default:
+    return &terminate{dump: t.dump}, nil
+  }
+}
+
+type uniqueIndexName struct {
+  dump *DumpSQL
+  name string
+}
+
+func (t *uniqueIndexName) next(token string) (state, error) {
+  switch token {
+  case "on":
+    return &uniqueIndexOn{dump: t.dump, name: t.name}, nil
+  default:
+    return &terminate{dump: t.dump}, nil
+  }
+}
+
+type uniqueIndexOn struct {
+  dump *DumpSQL
+  name string
+}
+
+func (t *uniqueIndexOn) next(token string) (state, error) {
+  token = trimEscapeQuotes(token)
+
+  switch {
+  case token == "only":
+    return t, nil
+
+  case matchTableName(token):
+    return &uniqueIndexTable{dump: t.dump, name: t.name, tableName: token}, nil
+
+  default:
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+}
+
+type uniqueIndexTable struct {
+  dump      *DumpSQL
+  name      string
+  tableName string
+}
+
+func (t *uniqueIndexTable) next(token string) (state, error) {
+  columns := &uniqueKeyColumns{
+    dump:      t.dump,
+    name:      t.name,
+    tableName: t.tableName,
+  }
+  switch {
+  case token == "using":
+    return &uniqueIndexMethod{columns: columns}, nil
+
+  case strings.HasPrefix(token, "("):
+    return columns.next(token)
+
+  default:
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+}
+
+type uniqueIndexMethod struct {
+  columns *uniqueKeyColumns
+}
+
+func (t *uniqueIndexMethod) next(_ string) (state, error) {
+  // Index method like btree is skipped
+  return t.columns, nil
+}
+
+// uniqueKeyColumns collects columns of the unique key like (a, b) until ";",
+// keys with expressions, options after columns (partial or deferrable keys)
+// cannot be used as conflict target and skipped
+type uniqueKeyColumns struct {
+  dump      *DumpSQL
+  name      string
+  tableName string
+
+  list    columnsList
+  skipped bool
+}
+
+func (t *uniqueKeyColumns) next(token string) (state, error) {
+  switch {
+  case token == ";":
+    if !t.list.closed {
+      return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+    }
+    if err := t.pushUniqueKey(); err != nil {
+      return nil, err
+    }
+    return &terminate{dump: t.dump}, nil
+
+  case t.list.closed:
+    t.skipped = true
+    return t, nil
+  }
+  // Options before columns like NULLS NOT DISTINCT skipped
+  t.list.push(token)
+
+  return t, nil
+}
+
+// columnsList collects columns from tokens like "(a", ",", "b)"
+type columnsList struct {
+  columns    []string
+  opened     bool
+  closed     bool
+  elem       int
+  expression bool
+}
+
+// push returns false for tokens before opening bracket
+func (l *columnsList) push(token string) bool {
+  if !l.opened && !strings.HasPrefix(token, "(") {
+    return false
+  }
+  if token == "," {
+    l.elem = 0
+    return true
+  }
+  column := token
+
+  if !l.opened {
+    column = strings.TrimPrefix(column, "(")
+    l.opened = true
+  }
+  if strings.HasSuffix(column, ")") {
+    column = strings.TrimSuffix(column, ")")
+    l.closed = true
+  }
+  column = trimEscapeQuotes(column)
+  l.elem++
+
+  // Column with sort options or expression
+  if l.elem > 1 || !matchColumnName(column) {
+    l.expression = true
+    return true
+  }
+  l.columns = append(l.columns, column)
+
+  return true
+}
+
+func (t *uniqueKeyColumns) pushUniqueKey() error {
+  if t.skipped || t.list.expression {
+    log.Debugf("pg_dump: unique key '%s' skipped: contains expressions or options", t.name)
+    return nil
+  }
+  tableIdx, ok := t.dump.tableIndex(t.tableName)
+  if !ok {
+    return fmt.Errorf("table name not found: unique key: %s", t.name)
+  }
+  var err error
+
+  t.dump.Tables.ElemWith(tableIdx, func(table *DumpTable) {
+    if column, ok := table.missingColumn(t.list.columns); ok {
+      err = fmt.Errorf("invalid unique key: table name: %s column: %s", t.tableName, column)
+      return
+    }
+    // Keys with the same columns are skipped
+    for _, key := range table.UniqueKeys {
+      if strings.Join(key.Columns, ",") == strings.Join(t.list.columns, ",") {
+        return
+      }
+    }
+    table.UniqueKeys = append(table.UniqueKeys, &DumpUniqueKey{
+      Name:    t.name,
+      Columns: t.list.columns,
+    })
+  })
+  return err
+}
+
+type foreignKey struct {
+  dump      *DumpSQL
+  name      string
+  tableName string
+}
+
+func (t *foreignKey) next(token string) (state, error) {
+  switch token {
+  case "key":
+    return &foreignKeyColumns{dump: t.dump, name: t.name, tableName: t.tableName}, nil
+  default:
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+}
+
+type foreignKeyColumns struct {
+  dump      *DumpSQL
+  name      string
+  tableName string
+
+  list columnsList
+}
+
+func (t *foreignKeyColumns) next(token string) (state, error) {
+  switch {
+  case t.list.closed && token == "references":
+    return &foreignKeyReferences{columns: t}, nil
+
+  case !t.list.closed && t.list.push(token):
+    return t, nil
+
+  default:
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+}
+
+type foreignKeyReferences struct {
+  columns *foreignKeyColumns
+}
+
+func (t *foreignKeyReferences) next(token string) (state, error) {
+  // Referenced columns may be written without space like table(id)
+  refRawName, refColumns, found := strings.Cut(token, "(")
+  refRawName = trimEscapeQuotes(refRawName)
+
+  if !matchTableName(refRawName) {
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+  refs := &foreignKeyRefColumns{
+    columns:    t.columns,
+    refRawName: refRawName,
+  }
+  if found {
+    refs.list.push("(" + refColumns)
+  }
+  return refs, nil
+}
+
+// foreignKeyRefColumns collects referenced columns until ";",
+// options after columns like ON DELETE CASCADE are skipped
+type foreignKeyRefColumns struct {
+  columns    *foreignKeyColumns
+  refRawName string
+
+  list columnsList
+}
+
+func (t *foreignKeyRefColumns) next(token string) (state, error) {
+  switch {
+  case token == ";" && t.list.closed:
+    if err := t.pushForeignKey(); err != nil {
+      return nil, err
+    }
+    return &terminate{dump: t.columns.dump}, nil
+
+  case t.list.closed:
+    return t, nil
+
+  case t.list.push(token):
+    return t, nil
+
+  default:
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+}
+
+func (t *foreignKeyRefColumns) pushForeignKey() error {
+  var (
+    dump      = t.columns.dump
+    name      = t.columns.name
+    tableName = t.columns.tableName
+    columns   = t.columns.list.columns
+  )
+  if t.columns.list.expression || t.list.expression || len(columns) != len(t.list.columns) {
+    return fmt.Errorf("invalid foreign key: table name: %s foreign key: %s", tableName, name)
+  }
+  tableIdx, ok := dump.tableIndex(tableName)
+  if !ok {
+    return fmt.Errorf("table name not found: foreign key: %s", name)
+  }
+  var err error
+
+  dump.Tables.ElemWith(tableIdx, func(table *DumpTable) {
+    if column, ok := table.missingColumn(columns); ok {
+      err = fmt.Errorf("invalid foreign key: table name: %s column: %s", tableName, column)
+      return
+    }
+    table.ForeignKeys = append(table.ForeignKeys, &DumpForeignKey{
+      Name:       name,
+      Columns:    columns,
+      RefRawName: t.refRawName,
+      RefColumns: t.list.columns,
+    })
+  })
+  return err
+}
+
 type table struct {
   dump *DumpSQL
 }
@@ -194,14 +514,37 @@ type constraint struct {
 }
 
 func (t *constraint) next(token string) (state, error) {
+  token = trimEscapeQuotes(token)
+
   switch {
   case matchPrimaryKeyConstraint(token):
     return &primaryKeyConstraintName{dump: t.dump}, nil
+  case matchConstraintName(token):
+    return &constraintName{dump: t.dump, name: token}, nil
   default:
     return &terminate{dump: t.dump}, nil
   }
 }
 
+type constraintName struct {
+  dump *DumpSQL
+  name string
+}
+
+func (t *constraintName) next(token string) (state, error) {
+  if token != "unique" && token != "foreign" {
+    return &terminate{dump: t.dump}, nil
+  }
+  tableName, ok := t.dump.tempStack.Pop()
+  if !ok {
+    return nil, fmt.Errorf("table name not found: constraint: %s", t.name)
+  }
+  if token == "unique" {
+    return &uniqueKeyColumns{dump: t.dump, name: t.name, tableName: tableName}, nil
+  }
+  return &foreignKey{dump: t.dump, name: t.name, tableName: tableName}, nil
+}
+
 type primaryKeyConstraintName struct {
   dump *DumpSQL
 }
@@ -230,49 +573,51 @@ func (t *primary) next(token string) (state, error) {
 
 type key struct {
   dump *DumpSQL
+  list columnsList
 }
 
 func (t *key) next(token string) (state, error) {
-  switch {
-  case matchPrimaryKeyName(token):
-    columnName := strings.Trim(token, "()")
+  if !t.list.push(token) {
+    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  }
+  if !t.list.closed {
+    return t, nil
+  }
+  if t.list.expression {
+    return nil, fmt.Errorf("invalid primary key: %v", t.list.columns)
+  }
+  rawTableName, ok := t.dump.tempStack.Pop()
+  if !ok {
+    return nil, fmt.Errorf("table name not found: primary key: %v", t.list.columns)
+  }
+  tableIdx, ok := t.dump.tableIndex(rawTableName)
+  if !ok {
+    return nil, fmt.Errorf("invalid primary key: table name: %s", rawTableName)
+  }
+  var err error
 
-    rawTableName, ok := t.dump.tempStack.Pop()
-    if !ok {
-      return nil, fmt.Errorf("table name not found: primary key: %s", token)
+  t.dump.Tables.ElemWith(tableIdx, func(table *DumpTable) {
+    if columnName, ok := table.missingColumn(t.list.columns); ok {
+      err = fmt.Errorf("invalid primary key: table name: %s column: %s", rawTableName, columnName)
+      return
     }
-    ok = false
+    table.PrimaryKey = t.list.columns
 
-    for tableIdx, table := range t.dump.Tables.Elems() {
-      if rawTableName != table.RawName {
+    for columnIdx, column := range table.Columns.Elems() {
+      if !lo.Contains(table.PrimaryKey, column.Name) {
         continue
       }
-      for columnIdx, column := range table.Columns.Elems() {
-        if columnName != column.Name {
-          continue
-        }
-        t.dump.Tables.ElemWith(tableIdx, func(table *DumpTable) {
-          table.Columns.ElemWith(columnIdx, func(column *DumpColumn) {
-            column.IsPrimaryKey = true
-            // Primary key column must contain not null constraint
-            column.IsNotNull = true
-          })
-        })
-        ok = true
-        break
-      }
-      if ok {
-        break
-      }
-    }
-    if !ok {
-      return nil, fmt.Errorf("invalid primary key: table name: %s column: %s", rawTableName, columnName)
+      table.Columns.ElemWith(columnIdx, func(column *DumpColumn) {
+        column.IsPrimaryKey = true
+        // Primary key column must contain not null constraint
+        column.IsNotNull = true
+      })
     }
-    return &primaryKeyName{dump: t.dump}, nil
-
-  default:
-    return nil, fmt.Errorf("%w: %s", errUnexpectedToken, token)
+  })
+  if err != nil {
+    return nil, err
   }
+  return &primaryKeyName{dump: t.dump}, nil
 }
 
 type primaryKeyName struct {
@@ -363,8 +708,14 @@ func (t *columnName) next(token string) (state, error) {
 
     "text",
     "uuid",
+    "citext",
+    "ltree",
 
     "date",
+    "interval",
+
+    "inet",
+    "cidr",
 
     // Slice of scalars column types
 
@@ -383,6 +734,7 @@ func (t *columnName) next(token string) (state, error) {
     "numeric[]",
 
     "text[]",
+    "uuid[]",
   ) ||
 
     // Character varying column types
@@ -392,7 +744,12 @@ func (t *columnName) next(token string) (state, error) {
 
     // Custom types from dump option
 
-    matchCustomType(token, t.dump.option):
+    matchCustomType(token, t.dump.option) ||
+
+    // Enum types from CREATE TYPE statements
+
+    matchEnumType(token, t.dump) ||
+    matchEnumArrayType(token, t.dump):
 
     return &scalarColumnTyp{dump: t.dump}, nil
 
@@ -403,6 +760,7 @@ func (t *columnName) next(token string) (state, error) {
 
   case stringer.StringOneOfEqual(token,
     "timestamp",
+    "timestamptz",
     "time",
   ):
     return &timeOrTimestampColumnTyp{dump: t.dump}, nil
@@ -694,6 +1052,17 @@ func matchCustomType(customType string, options DumpOption) bool {
   return ok
 }
 
+func matchEnumType(enumType string, dump *DumpSQL) bool {
+  return lo.ContainsBy(dump.Enums, func(enum *DumpEnum) bool {
+    return enum.Name == enumType
+  })
+}
+
+func matchEnumArrayType(enumArrayType string, dump *DumpSQL) bool {
+  enumType := strings.TrimSuffix(enumArrayType, "[]")
+  return enumType != enumArrayType && matchEnumType(enumType, dump)
+}
+
 var (
   matchTableName                  = regexp.MustCompile(`^(\w+\.)?\w+$`).MatchString
   matchColumnName                 = regexp.MustCompile(`^\w+$`).MatchString
@@ -701,7 +1070,7 @@ var (
   matchNVarcharColumnTyp          = regexp.MustCompile(`^(n)+varchar(\(\d+\))?$`).MatchString
   matchCharacterVaryingOption     = regexp.MustCompile(`^varying(\(\d+\)?)*$`).MatchString
   matchPrimaryKeyConstraint       = regexp.MustCompile(`^\w+_pkey$`).MatchString
-  matchPrimaryKeyName             = regexp.MustCompile(`^(\w+)|\(\w+\)$`).MatchString
+  matchConstraintName             = regexp.MustCompile(`^\w+$`).MatchString
 )
 
 var errUnexpectedToken = errors.New("unexpected token")
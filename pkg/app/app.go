@@ -26,6 +26,7 @@ import (
   "google.golang.org/grpc"
   "google.golang.org/grpc/reflection"
 
+  configHandler "github.com/ushakovn/boiler/pkg/config/handler"
   metrics "github.com/ushakovn/boiler/pkg/metrics/handler"
 )
 
@@ -116,6 +117,9 @@ func NewApp(calls ...Option) *App {
 func registerPreRunComponents() {
   // Config components
   registerConfigClient()
+
+  // Log options from config
+  registerLogOptions()
 }
 
 func registerConfigClient() {
@@ -123,6 +127,11 @@ func registerConfigClient() {
   log.Infof("boiler: config client registered")
 }
 
+func registerLogOptions() {
+  logger.WatchConfigOptions(context.Background())
+  log.Infof("boiler: log options watch registered")
+}
+
 func (a *App) Run(services ...Service) {
   defer func() {
     if rec := recover(); rec != nil {
@@ -204,6 +213,7 @@ func (a *App) registerServicesComponents(params *RegisterParams, _ ...Service) {
 
   // Developer help components
   a.registerHelpHandler()
+  a.registerConfigHandler()
 
   // Run duty HTTP router
   a.runHttpDutyRouter()
@@ -379,6 +389,12 @@ func (a *App) registerGrpcSwagger(params *GrpcParams) {
   }
 }
 
+func (a *App) registerConfigHandler() {
+  configHandler.WithConfigHandler(a.dutyHttpRouter)
+
+  log.Infof("boiler: config handler registered")
+}
+
 func (a *App) registerHelpHandler() {
   marshaledHelp, err := a.marshalHelpInfo()
   if err != nil {
@@ -399,10 +415,8 @@ func (a *App) marshalHelpInfo() ([]byte, error) {
     GrpcPort          int `json:"grpc_port"`
     GrpcHttpProxyPort int `json:"grpc_http_proxy_port"`
   }
-  const dutyServePort = 8092
-
   marshaledHelp, err := json.Marshal(&HelpInfo{
-    DutyHttpPort:      dutyServePort,
+    DutyHttpPort:      a.dutyHttpPort,
     GqlgenPort:        a.gqlgenPort,
     GrpcPort:          a.grpcPort,
     GrpcHttpProxyPort: a.grpcHttpProxyPort,
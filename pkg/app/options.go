@@ -5,6 +5,7 @@ import (
 
   "github.com/99designs/gqlgen/graphql"
   mw "github.com/grpc-ecosystem/go-grpc-middleware"
+  "github.com/ushakovn/boiler/pkg/env"
   metrics "github.com/ushakovn/boiler/pkg/metrics/middlewares"
   recover "github.com/ushakovn/boiler/pkg/recover/middlewares"
   tracing "github.com/ushakovn/boiler/pkg/tracing/middlewares"
@@ -39,18 +40,14 @@ type calledAppOptions struct {
 }
 
 func defaultOptions() []Option {
-  const (
-    defaultGrpcPort          = 8082
-    defaultGrpcHttpProxyPort = 8084
-    defaultGqlgenPort        = 8080
-    defaultDutyHttpPort      = 8092
-  )
+  ports := env.Boiler().Ports
+
   options := []Option{
-    // Port options
-    WithGrpcServePort(defaultGrpcPort),
-    WithGrpcHttpProxyPort(defaultGrpcHttpProxyPort),
-    WithGqlgenServePort(defaultGqlgenPort),
-    WithDutyHttpServePort(defaultDutyHttpPort),
+    // Port options from env
+    WithGrpcServePort(ports.Grpc),
+    WithGrpcHttpProxyPort(ports.GrpcHttpProxy),
+    WithGqlgenServePort(ports.Gqlgen),
+    WithDutyHttpServePort(ports.DutyHttp),
 
     // Panic recover options
     WithGrpcUnaryServerInterceptors(recover.GrpcServerUnaryInterceptor),
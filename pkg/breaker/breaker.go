@@ -0,0 +1,272 @@
+package breaker
+
+import (
+  "context"
+  "errors"
+  "sync"
+  "time"
+
+  log "github.com/sirupsen/logrus"
+)
+
+var (
+  ErrOpenState       = errors.New("breaker: open state")
+  ErrTooManyRequests = errors.New("breaker: too many requests")
+)
+
+type State uint32
+
+const (
+  StateClosed   State = 0
+  StateHalfOpen State = 1
+  StateOpen     State = 2
+)
+
+func (s State) String() string {
+  switch s {
+  case StateClosed:
+    return "closed"
+  case StateHalfOpen:
+    return "half_open"
+  case StateOpen:
+    return "open"
+  }
+  return "unknown"
+}
+
+// Counts requests statistic for the current breaker generation
+type Counts struct {
+  Requests             uint32
+  Successes            uint32
+  Failures             uint32
+  ConsecutiveSuccesses uint32
+  ConsecutiveFailures  uint32
+}
+
+func (c *Counts) onRequest() {
+  c.Requests++
+}
+
+func (c *Counts) onSuccess() {
+  c.Successes++
+  c.ConsecutiveSuccesses++
+  c.ConsecutiveFailures = 0
+}
+
+// onIgnored releases the request of the ignored call
+func (c *Counts) onIgnored() {
+  c.Requests--
+}
+
+func (c *Counts) onFailure() {
+  c.Failures++
+  c.ConsecutiveFailures++
+  c.ConsecutiveSuccesses = 0
+}
+
+type callResult uint8
+
+const (
+  callSuccess callResult = iota
+  callFailure
+  // Ignored call like canceled one affects neither counts nor state
+  callIgnored
+)
+
+type Breaker struct {
+  name   string
+  config config
+
+  mu         sync.Mutex
+  state      State
+  generation uint64
+  counts     Counts
+  expiry     time.Time
+}
+
+func New(name string, calls ...Option) *Breaker {
+  options := callOptions(calls...)
+
+  // Metrics registered once for all breakers
+  initMetrics()
+
+  b := &Breaker{
+    name:   name,
+    config: options.config,
+  }
+  b.toNewGeneration(b.config.now())
+  m.observeState(b.name, b.state)
+
+  return b
+}
+
+func (b *Breaker) Name() string {
+  return b.name
+}
+
+func (b *Breaker) State() State {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  state, _ := b.currentState(b.config.now())
+  return state
+}
+
+func (b *Breaker) Counts() Counts {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  return b.counts
+}
+
+// Do calls f if the breaker accepts requests,
+// returns ErrOpenState or ErrTooManyRequests otherwise
+func (b *Breaker) Do(ctx context.Context, f func(ctx context.Context) error) error {
+  generation, err := b.beforeCall()
+  if err != nil {
+    m.incRequests(b.name, resultRejected)
+    return err
+  }
+
+  defer func() {
+    if rec := recover(); rec != nil {
+      // Panic counts as failure
+      b.afterCall(generation, callFailure)
+      panic(rec)
+    }
+  }()
+
+  err = f(ctx)
+  b.afterCall(generation, b.classify(err))
+
+  return err
+}
+
+func (b *Breaker) classify(err error) callResult {
+  switch {
+  case b.config.isIgnored(err):
+    return callIgnored
+  case b.config.isFailure(err):
+    return callFailure
+  }
+  return callSuccess
+}
+
+func (b *Breaker) beforeCall() (uint64, error) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  state, generation := b.currentState(b.config.now())
+
+  switch {
+  case state == StateOpen:
+    return generation, ErrOpenState
+
+  case state == StateHalfOpen && b.counts.Requests >= b.config.halfOpenMaxRequests:
+    return generation, ErrTooManyRequests
+  }
+  b.counts.onRequest()
+
+  return generation, nil
+}
+
+func (b *Breaker) afterCall(before uint64, result callResult) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  now := b.config.now()
+  state, generation := b.currentState(now)
+
+  switch result {
+  case callSuccess:
+    m.incRequests(b.name, resultSuccess)
+  case callFailure:
+    m.incRequests(b.name, resultFailure)
+  case callIgnored:
+    m.incRequests(b.name, resultIgnored)
+  }
+  // Result of the outdated generation is skipped
+  if generation != before {
+    return
+  }
+  switch result {
+  case callSuccess:
+    b.onSuccess(state, now)
+  case callFailure:
+    b.onFailure(state, now)
+  case callIgnored:
+    // Half open request slot is released for the next probe
+    b.counts.onIgnored()
+  }
+}
+
+func (b *Breaker) onSuccess(state State, now time.Time) {
+  b.counts.onSuccess()
+
+  if state == StateHalfOpen && b.counts.ConsecutiveSuccesses >= b.config.halfOpenMaxRequests {
+    b.setState(StateClosed, now)
+  }
+}
+
+func (b *Breaker) onFailure(state State, now time.Time) {
+  b.counts.onFailure()
+
+  switch state {
+  case StateClosed:
+    if b.config.tripPolicy(b.counts) {
+      b.setState(StateOpen, now)
+    }
+  case StateHalfOpen:
+    b.setState(StateOpen, now)
+  }
+}
+
+func (b *Breaker) currentState(now time.Time) (State, uint64) {
+  switch b.state {
+  case StateClosed:
+    if !b.expiry.IsZero() && b.expiry.Before(now) {
+      b.toNewGeneration(now)
+    }
+  case StateOpen:
+    if b.expiry.Before(now) {
+      b.setState(StateHalfOpen, now)
+    }
+  }
+  return b.state, b.generation
+}
+
+func (b *Breaker) setState(state State, now time.Time) {
+  if b.state == state {
+    return
+  }
+  prev := b.state
+  b.state = state
+
+  b.toNewGeneration(now)
+
+  m.observeState(b.name, state)
+  m.incStateChanges(b.name, prev, state)
+
+  log.Warnf("breaker: %s state changed: %s -> %s", b.name, prev, state)
+
+  if f := b.config.onStateChange; f != nil {
+    f(b.name, prev, state)
+  }
+}
+
+func (b *Breaker) toNewGeneration(now time.Time) {
+  b.generation++
+  b.counts = Counts{}
+
+  var expiry time.Time
+
+  switch b.state {
+  case StateClosed:
+    if b.config.interval > 0 {
+      expiry = now.Add(b.config.interval)
+    }
+  case StateOpen:
+    expiry = now.Add(b.config.openTimeout)
+  }
+  b.expiry = expiry
+}
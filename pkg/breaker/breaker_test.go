@@ -0,0 +1,121 @@
+package breaker
+
+import (
+  "context"
+  "errors"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+)
+
+type testClock struct {
+  now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+  return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+  c.now = c.now.Add(d)
+}
+
+var errTest = errors.New("test error")
+
+func doTest(b *Breaker, err error) error {
+  return b.Do(context.Background(), func(context.Context) error {
+    return err
+  })
+}
+
+func Test_BreakerConsecutiveFailures(t *testing.T) {
+  clock := &testClock{now: time.Now()}
+
+  b := New("test_consecutive",
+    WithTripPolicy(ConsecutiveFailures(3)),
+    WithOpenTimeout(time.Second),
+    withNow(clock.Now),
+  )
+  for i := 0; i < 2; i++ {
+    assert.Equal(t, errTest, doTest(b, errTest))
+  }
+  assert.Equal(t, StateClosed, b.State())
+
+  // Success resets consecutive failures
+  assert.Equal(t, nil, doTest(b, nil))
+  for i := 0; i < 3; i++ {
+    assert.Equal(t, errTest, doTest(b, errTest))
+  }
+  assert.Equal(t, StateOpen, b.State())
+  assert.Equal(t, ErrOpenState, doTest(b, nil))
+
+  // Open timeout elapsed
+  clock.Advance(2 * time.Second)
+  assert.Equal(t, StateHalfOpen, b.State())
+
+  assert.Equal(t, nil, doTest(b, nil))
+  assert.Equal(t, StateClosed, b.State())
+}
+
+func Test_BreakerHalfOpenFailure(t *testing.T) {
+  clock := &testClock{now: time.Now()}
+
+  b := New("test_half_open",
+    WithTripPolicy(ConsecutiveFailures(1)),
+    WithOpenTimeout(time.Second),
+    withNow(clock.Now),
+  )
+  assert.Equal(t, errTest, doTest(b, errTest))
+  assert.Equal(t, StateOpen, b.State())
+
+  clock.Advance(2 * time.Second)
+  assert.Equal(t, errTest, doTest(b, errTest))
+  assert.Equal(t, StateOpen, b.State())
+}
+
+func Test_BreakerFailureRatio(t *testing.T) {
+  clock := &testClock{now: time.Now()}
+
+  b := New("test_ratio",
+    WithTripPolicy(FailureRatio(0.5, 4)),
+    withNow(clock.Now),
+  )
+  assert.Equal(t, nil, doTest(b, nil))
+  assert.Equal(t, errTest, doTest(b, errTest))
+  assert.Equal(t, nil, doTest(b, nil))
+  assert.Equal(t, StateClosed, b.State())
+
+  assert.Equal(t, errTest, doTest(b, errTest))
+  assert.Equal(t, StateOpen, b.State())
+}
+
+func Test_BreakerCanceledIsNotFailure(t *testing.T) {
+  b := New("test_canceled", WithTripPolicy(ConsecutiveFailures(1)))
+
+  assert.Equal(t, context.Canceled, doTest(b, context.Canceled))
+  assert.Equal(t, StateClosed, b.State())
+  assert.Equal(t, Counts{}, b.Counts())
+}
+
+func Test_BreakerHalfOpenCanceledIsIgnored(t *testing.T) {
+  clock := &testClock{now: time.Now()}
+
+  b := New("test_half_open_canceled",
+    WithTripPolicy(ConsecutiveFailures(1)),
+    WithOpenTimeout(time.Second),
+    withNow(clock.Now),
+  )
+  assert.Equal(t, errTest, doTest(b, errTest))
+
+  clock.Advance(2 * time.Second)
+  assert.Equal(t, StateHalfOpen, b.State())
+
+  // Canceled probe neither closes the breaker nor holds the request slot
+  assert.Equal(t, context.Canceled, doTest(b, context.Canceled))
+  assert.Equal(t, StateHalfOpen, b.State())
+  assert.Equal(t, Counts{}, b.Counts())
+
+  assert.Equal(t, errTest, doTest(b, errTest))
+  assert.Equal(t, StateOpen, b.State())
+}
@@ -0,0 +1,80 @@
+package breaker
+
+import (
+  "sync"
+
+  "github.com/prometheus/client_golang/prometheus"
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/metrics"
+)
+
+const (
+  resultSuccess  = "success"
+  resultFailure  = "failure"
+  resultRejected = "rejected"
+  resultIgnored  = "ignored"
+)
+
+type breakerMetrics struct {
+  state        *prometheus.GaugeVec
+  requests     *prometheus.CounterVec
+  stateChanges *prometheus.CounterVec
+}
+
+var (
+  m    *breakerMetrics
+  once sync.Once
+)
+
+func initMetrics() {
+  once.Do(func() {
+    // Current state of the breaker
+    stateGauge := metrics.NewGaugeVec(
+      "breaker_state_gauge",
+      "Gauge of circuit breaker state: 0 - closed, 1 - half open, 2 - open",
+      []string{"breaker"},
+    )
+    // Requests passed through the breaker
+    requestsCounter := metrics.NewCounterVec(
+      "breaker_request_counter",
+      "Counter of circuit breaker requests",
+      []string{"breaker", "result"},
+    )
+    // State transitions of the breaker
+    stateChangesCounter := metrics.NewCounterVec(
+      "breaker_state_change_counter",
+      "Counter of circuit breaker state changes",
+      []string{"breaker", "from", "to"},
+    )
+
+    m = &breakerMetrics{
+      state:        stateGauge,
+      requests:     requestsCounter,
+      stateChanges: stateChangesCounter,
+    }
+  })
+}
+
+func (m *breakerMetrics) observeState(name string, state State) {
+  if gauge, err := m.state.GetMetricWithLabelValues(name); err != nil {
+    log.Errorf("breaker: state gauge error: %v", err)
+  } else {
+    gauge.Set(float64(state))
+  }
+}
+
+func (m *breakerMetrics) incRequests(name, result string) {
+  if counter, err := m.requests.GetMetricWithLabelValues(name, result); err != nil {
+    log.Errorf("breaker: request counter error: %v", err)
+  } else {
+    counter.Inc()
+  }
+}
+
+func (m *breakerMetrics) incStateChanges(name string, from, to State) {
+  if counter, err := m.stateChanges.GetMetricWithLabelValues(name, from.String(), to.String()); err != nil {
+    log.Errorf("breaker: state change counter error: %v", err)
+  } else {
+    counter.Inc()
+  }
+}
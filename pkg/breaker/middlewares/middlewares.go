@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+  "context"
+  "errors"
+
+  "github.com/ushakovn/boiler/pkg/breaker"
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+var grpcFailureCodes = map[codes.Code]struct{}{
+  codes.Unknown:           {},
+  codes.DeadlineExceeded:  {},
+  codes.ResourceExhausted: {},
+  codes.Internal:          {},
+  codes.Unavailable:       {},
+}
+
+// IsGrpcFailure reports only dependency failures,
+// client errors like InvalidArgument or NotFound do not trip the breaker
+func IsGrpcFailure(err error) bool {
+  if !breaker.IsFailure(err) {
+    return false
+  }
+  _, ok := grpcFailureCodes[status.Code(err)]
+  return ok
+}
+
+// IsGrpcIgnored reports canceled calls including canceled gRPC status
+func IsGrpcIgnored(err error) bool {
+  return breaker.IsIgnored(err) || status.Code(err) == codes.Canceled
+}
+
+// GrpcClientUnaryInterceptor guards outbound gRPC calls with the breaker
+func GrpcClientUnaryInterceptor(b *breaker.Breaker) grpc.UnaryClientInterceptor {
+  return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+    err := b.Do(ctx, func(ctx context.Context) error {
+      return invoker(ctx, method, req, reply, cc, opts...)
+    })
+    if errors.Is(err, breaker.ErrOpenState) || errors.Is(err, breaker.ErrTooManyRequests) {
+      // Rejected calls look like unavailable dependency
+      return status.Errorf(codes.Unavailable, "%s: %v", method, err)
+    }
+    return err
+  }
+}
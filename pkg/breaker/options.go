@@ -0,0 +1,157 @@
+package breaker
+
+import (
+  "context"
+  "errors"
+  "time"
+)
+
+type Option func(*calledOptions)
+
+type calledOptions struct {
+  // Embed config
+  config
+}
+
+type config struct {
+  // Period of the closed state counts reset, never reset if zero
+  interval time.Duration
+  // Period of the open state before switching to half open
+  openTimeout time.Duration
+  // Requests allowed in the half open state
+  halfOpenMaxRequests uint32
+
+  tripPolicy    TripPolicy
+  isFailure     func(err error) bool
+  isIgnored     func(err error) bool
+  onStateChange func(name string, from, to State)
+
+  now func() time.Time
+}
+
+// TripPolicy decides whether the closed breaker must be opened
+type TripPolicy func(counts Counts) bool
+
+// ConsecutiveFailures trips the breaker after threshold failures in a row
+func ConsecutiveFailures(threshold uint32) TripPolicy {
+  return func(counts Counts) bool {
+    return counts.ConsecutiveFailures >= threshold
+  }
+}
+
+// FailureRatio trips the breaker when the failures ratio reaches ratio
+// and at least minRequests were made in the current generation
+func FailureRatio(ratio float64, minRequests uint32) TripPolicy {
+  return func(counts Counts) bool {
+    if counts.Requests == 0 || counts.Requests < minRequests {
+      return false
+    }
+    return float64(counts.Failures)/float64(counts.Requests) >= ratio
+  }
+}
+
+// AnyOf trips the breaker when any of policies trips it
+func AnyOf(policies ...TripPolicy) TripPolicy {
+  return func(counts Counts) bool {
+    for _, policy := range policies {
+      if policy(counts) {
+        return true
+      }
+    }
+    return false
+  }
+}
+
+func IsFailure(err error) bool {
+  return err != nil && !errors.Is(err, context.Canceled)
+}
+
+// IsIgnored reports canceled calls, they are counted neither as success nor as failure
+func IsIgnored(err error) bool {
+  return errors.Is(err, context.Canceled)
+}
+
+func WithInterval(interval time.Duration) Option {
+  return func(o *calledOptions) {
+    o.interval = interval
+  }
+}
+
+func WithOpenTimeout(timeout time.Duration) Option {
+  return func(o *calledOptions) {
+    o.openTimeout = timeout
+  }
+}
+
+func WithHalfOpenMaxRequests(count uint32) Option {
+  return func(o *calledOptions) {
+    o.halfOpenMaxRequests = count
+  }
+}
+
+func WithTripPolicy(policy TripPolicy) Option {
+  return func(o *calledOptions) {
+    o.tripPolicy = policy
+  }
+}
+
+func WithFailureClassifier(isFailure func(err error) bool) Option {
+  return func(o *calledOptions) {
+    o.isFailure = isFailure
+  }
+}
+
+func WithIgnoredClassifier(isIgnored func(err error) bool) Option {
+  return func(o *calledOptions) {
+    o.isIgnored = isIgnored
+  }
+}
+
+func WithOnStateChange(f func(name string, from, to State)) Option {
+  return func(o *calledOptions) {
+    o.onStateChange = f
+  }
+}
+
+func WithDefaultConfig() Option {
+  const (
+    interval            = time.Minute
+    openTimeout         = 10 * time.Second
+    halfOpenMaxRequests = 1
+    consecutiveFailures = 5
+  )
+  return func(o *calledOptions) {
+    o.config = config{
+      interval:            interval,
+      openTimeout:         openTimeout,
+      halfOpenMaxRequests: halfOpenMaxRequests,
+      tripPolicy:          ConsecutiveFailures(consecutiveFailures),
+      isFailure:           IsFailure,
+      isIgnored:           IsIgnored,
+      now:                 time.Now,
+    }
+  }
+}
+
+func withNow(now func() time.Time) Option {
+  return func(o *calledOptions) {
+    o.now = now
+  }
+}
+
+func callOptions(calls ...Option) *calledOptions {
+  calls = append(defaultOptions(), calls...)
+  o := new(calledOptions)
+
+  for _, call := range calls {
+    call(o)
+  }
+  if o.halfOpenMaxRequests == 0 {
+    o.halfOpenMaxRequests = 1
+  }
+  return o
+}
+
+func defaultOptions() []Option {
+  return []Option{WithDefaultConfig()}
+}
@@ -9,19 +9,28 @@ import (
 
 type Client interface {
   GetAppInfo() AppInfo
+  GetKeys() []string
   GetValue(ctx context.Context, key string) types.Value
+  GetValueSource(ctx context.Context, key string) (types.Value, provider.Source)
+  // WatchValue delivers the current value once on subscribing and then each change
   WatchValue(ctx context.Context, key string, action func(types.Value))
+  // WatchChanges delivers only changes
+  WatchChanges(ctx context.Context, key string, action func(ChangeEvent))
 }
 
 type configClient struct {
   app       AppInfo
+  keys      []string
   providers []provider.Values
+  hub       *watchHub
 }
 
-func newClient(app AppInfo, providers ...provider.Values) Client {
+func newClient(app AppInfo, keys []string, providers ...provider.Values) Client {
   return &configClient{
     app:       app,
+    keys:      keys,
     providers: providers,
+    hub:       newWatchHub(defaultWatchDebounce, providers...),
   }
 }
 
@@ -29,21 +38,39 @@ func (c *configClient) GetAppInfo() AppInfo {
   return c.app
 }
 
+func (c *configClient) GetKeys() []string {
+  return c.keys
+}
+
 func (c *configClient) GetValue(ctx context.Context, key string) types.Value {
+  value, _ := c.GetValueSource(ctx, key)
+  return value
+}
+
+func (c *configClient) GetValueSource(ctx context.Context, key string) (types.Value, provider.Source) {
   for _, p := range c.providers {
-    if value := p.Get(ctx, key); !value.IsNil() {
-      return value
+    value := p.Get(ctx, key)
+
+    if value == nil || value.IsNil() {
+      continue
+    }
+    if sourcer, ok := p.(provider.Sourcer); ok {
+      return value, sourcer.Source(ctx, key)
     }
+    return value, provider.Source{Layer: provider.LayerUnknown}
   }
-  return types.NewNilValue()
+  return types.NewNilValue(), provider.Source{}
 }
 
+// WatchValue calls action with the current effective value on subscribing
+// and then with the new effective value on each change until ctx done
 func (c *configClient) WatchValue(ctx context.Context, key string, action func(types.Value)) {
-  go func(ctx context.Context) {
-    for _, p := range c.providers {
-      p.Watch(ctx, key, action)
-    }
-  }(ctx)
+  c.hub.subscribeValue(ctx, key, action)
+}
+
+// WatchChanges calls action with the change event on each change until ctx done
+func (c *configClient) WatchChanges(ctx context.Context, key string, action func(ChangeEvent)) {
+  c.hub.subscribe(ctx, key, action)
 }
 
 type noopConfigClient struct{}
@@ -63,10 +90,20 @@ func (c *noopConfigClient) GetAppInfo() AppInfo {
   }
 }
 
+func (c *noopConfigClient) GetKeys() []string {
+  return nil
+}
+
 func (c *noopConfigClient) GetValue(_ context.Context, _ string) types.Value {
   return types.NewNilValue()
 }
 
+func (c *noopConfigClient) GetValueSource(_ context.Context, _ string) (types.Value, provider.Source) {
+  return types.NewNilValue(), provider.Source{}
+}
+
 func (c *noopConfigClient) WatchValue(_ context.Context, _ string, action func(types.Value)) {
   action(types.NewNilValue())
 }
+
+func (c *noopConfigClient) WatchChanges(_ context.Context, _ string, _ func(ChangeEvent)) {}
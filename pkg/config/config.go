@@ -1,12 +1,11 @@
 package config
 
 import (
-  "path/filepath"
   "sync"
 
   log "github.com/sirupsen/logrus"
   "github.com/ushakovn/boiler/pkg/config/provider/etcd"
-  "github.com/ushakovn/boiler/pkg/config/provider/local"
+  "github.com/ushakovn/boiler/pkg/config/provider/file"
 )
 
 var (
@@ -21,7 +20,7 @@ var (
 func InitClient() {
   once.Do(func() {
     // Default config path
-    path := filepath.Join(".config", "app_config.yaml")
+    path := BaseConfigPath()
 
     if !findConfig(path) {
       log.Warnf("config: file not found: %s", path)
@@ -29,23 +28,37 @@ func InitClient() {
       client = newNoopClient()
       return
     }
-    parsed, err := ParseConfig(path)
+    layered, err := loadLayeredConfig(path)
     if err != nil {
-      log.Fatalf("config: parsing failed:\n%v", err)
+      log.Fatalf("config: loading failed:\n%v", err)
     }
-    if err = parsed.Validate(); err != nil {
-      log.Fatalf("config: validation failed:\n%v", err)
-    }
-    app := collectAppInfo(parsed.App)
+    app := collectAppInfo(layered.parsed.App)
+    setLoadedParsed(layered.parsed)
 
-    values, err := collectConfigValues(parsed.Custom)
-    if err != nil {
-      log.Fatalf("boiler: values collecting failed: %v", err)
-    }
     // Use config client
-    client = newClient(app,
-      etcd.New(etcd.WithAppName(app.Name)),
-      local.New(values),
+    client = newClient(app, layered.parsed.Custom.keys(),
+      etcd.New(
+        etcd.WithAppName(app.Name),
+        etcd.WithSpecs(layered.parsed.Custom.specs()),
+      ),
+      file.New(
+        &file.Snapshot{
+          Values:  layered.values,
+          Sources: layered.sources,
+        },
+        // Reload with validation of all layers
+        func() (*file.Snapshot, error) {
+          layered, err := loadLayeredConfig(path)
+          if err != nil {
+            return nil, err
+          }
+          return &file.Snapshot{
+            Values:  layered.values,
+            Sources: layered.sources,
+          }, nil
+        },
+        file.WithPaths(watchedConfigPaths(path)...),
+      ),
     )
   })
 }
@@ -0,0 +1,302 @@
+package configtest
+
+import (
+  "context"
+  "fmt"
+  "reflect"
+  "sort"
+  "sync"
+  "testing"
+
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+const sourceOrigin = "configtest"
+
+// Client in-memory config client for tests:
+// values are set and deleted at runtime, watches are called synchronously
+type Client struct {
+  mu       sync.Mutex
+  app      config.AppInfo
+  values   map[string]types.Value
+  reads    map[string]int
+  watchers map[string]map[uint64]*watcher
+  nextID   uint64
+}
+
+type watcher struct {
+  ctx    context.Context
+  action func(config.ChangeEvent)
+}
+
+var _ config.Client = (*Client)(nil)
+
+func New() *Client {
+  return &Client{
+    app: config.AppInfo{
+      Name:    "app",
+      Version: "v0.0.0",
+    },
+    values:   map[string]types.Value{},
+    reads:    map[string]int{},
+    watchers: map[string]map[uint64]*watcher{},
+  }
+}
+
+// FromYAML creates client with values of the fixture
+// in the app_config.yaml format, app section is optional
+func FromYAML(fixture string) (*Client, error) {
+  parsed, err := config.ParseConfigData([]byte(fixture))
+  if err != nil {
+    return nil, fmt.Errorf("fixture parsing failed: %w", err)
+  }
+  if err = parsed.Custom.Validate(); err != nil {
+    return nil, fmt.Errorf("fixture validation failed:\n%v", err)
+  }
+  values, err := parsed.Custom.Values()
+  if err != nil {
+    return nil, fmt.Errorf("fixture values collecting failed: %w", err)
+  }
+  c := New()
+  c.values = values
+
+  if app := parsed.App; app != nil {
+    c.app = config.AppInfo{
+      Name:        app.Name,
+      Version:     app.Version,
+      Description: app.Description,
+    }
+  }
+  return c, nil
+}
+
+// MustFromYAML creates client with values of the fixture or fails the test
+func MustFromYAML(t testing.TB, fixture string) *Client {
+  t.Helper()
+
+  c, err := FromYAML(fixture)
+  if err != nil {
+    t.Fatalf("configtest: %v", err)
+  }
+  return c
+}
+
+// Context returns context with the client
+func (c *Client) Context(parent context.Context) context.Context {
+  return config.ContextWithClient(parent, c)
+}
+
+func (c *Client) SetAppInfo(app config.AppInfo) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  c.app = app
+}
+
+// Set sets value of the key and calls watches of the key if value changed
+func (c *Client) Set(key string, value any) {
+  c.SetValue(key, types.NewValue(value))
+}
+
+// SetValue sets value of the key and calls watches of the key if value changed
+func (c *Client) SetValue(key string, value types.Value) {
+  c.update(key, value)
+}
+
+// Delete deletes value of the key and calls watches of the key with nil value
+func (c *Client) Delete(key string) {
+  c.update(key, types.NewNilValue())
+}
+
+// Reads returns count of the key reads
+func (c *Client) Reads(key string) int {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  return c.reads[key]
+}
+
+// ReadKeys returns sorted keys which were read
+func (c *Client) ReadKeys() []string {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  keys := make([]string, 0, len(c.reads))
+
+  for key := range c.reads {
+    keys = append(keys, key)
+  }
+  sort.Strings(keys)
+
+  return keys
+}
+
+// ResetReads resets counts of the reads
+func (c *Client) ResetReads() {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  c.reads = map[string]int{}
+}
+
+// AssertRead fails the test if any of the keys was not read
+func (c *Client) AssertRead(t testing.TB, keys ...string) {
+  t.Helper()
+
+  for _, key := range keys {
+    if c.Reads(key) == 0 {
+      t.Errorf("configtest: key %s was not read, read keys: %v", key, c.ReadKeys())
+    }
+  }
+}
+
+// AssertNotRead fails the test if any of the keys was read
+func (c *Client) AssertNotRead(t testing.TB, keys ...string) {
+  t.Helper()
+
+  for _, key := range keys {
+    if reads := c.Reads(key); reads != 0 {
+      t.Errorf("configtest: key %s was read %d times", key, reads)
+    }
+  }
+}
+
+func (c *Client) GetAppInfo() config.AppInfo {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  return c.app
+}
+
+func (c *Client) GetKeys() []string {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  keys := make([]string, 0, len(c.values))
+
+  for key := range c.values {
+    keys = append(keys, key)
+  }
+  sort.Strings(keys)
+
+  return keys
+}
+
+func (c *Client) GetValue(ctx context.Context, key string) types.Value {
+  value, _ := c.GetValueSource(ctx, key)
+  return value
+}
+
+func (c *Client) GetValueSource(_ context.Context, key string) (types.Value, provider.Source) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  c.reads[key]++
+
+  value, ok := c.values[key]
+  if !ok {
+    return types.NewNilValue(), provider.Source{}
+  }
+  return value, provider.Source{
+    Layer:  provider.LayerUnknown,
+    Origin: sourceOrigin,
+  }
+}
+
+// WatchValue calls action with the current value on subscribing
+// and then with the new value on each change until ctx done
+func (c *Client) WatchValue(ctx context.Context, key string, action func(types.Value)) {
+  c.mu.Lock()
+  current, ok := c.values[key]
+  c.mu.Unlock()
+
+  if !ok {
+    current = types.NewNilValue()
+  }
+  action(current)
+
+  c.WatchChanges(ctx, key, func(event config.ChangeEvent) {
+    action(event.NewValue)
+  })
+}
+
+// WatchChanges calls action with the change event on each change until ctx done
+func (c *Client) WatchChanges(ctx context.Context, key string, action func(config.ChangeEvent)) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  if c.watchers[key] == nil {
+    c.watchers[key] = map[uint64]*watcher{}
+  }
+  c.watchers[key][c.nextID] = &watcher{
+    ctx:    ctx,
+    action: action,
+  }
+  c.nextID++
+}
+
+func (c *Client) update(key string, next types.Value) {
+  c.mu.Lock()
+
+  prev, ok := c.values[key]
+  if !ok {
+    prev = types.NewNilValue()
+  }
+  if next.IsNil() {
+    delete(c.values, key)
+  } else {
+    c.values[key] = next
+  }
+  if equalValues(prev, next) {
+    c.mu.Unlock()
+    return
+  }
+  event := config.ChangeEvent{
+    Key:      key,
+    OldValue: prev,
+    NewValue: next,
+    Deleted:  next.IsNil(),
+  }
+  if !event.Deleted {
+    event.Source = provider.Source{
+      Layer:  provider.LayerUnknown,
+      Origin: sourceOrigin,
+    }
+  }
+  ids := make([]uint64, 0, len(c.watchers[key]))
+
+  for id := range c.watchers[key] {
+    ids = append(ids, id)
+  }
+  // Watches called in order of subscription
+  sort.Slice(ids, func(i, j int) bool {
+    return ids[i] < ids[j]
+  })
+  var actions []func(config.ChangeEvent)
+
+  for _, id := range ids {
+    w := c.watchers[key][id]
+
+    if w.ctx.Err() != nil {
+      // Watch stopped
+      delete(c.watchers[key], id)
+      continue
+    }
+    actions = append(actions, w.action)
+  }
+  c.mu.Unlock()
+
+  // Actions may read the client
+  for _, action := range actions {
+    action(event)
+  }
+}
+
+func equalValues(prev, next types.Value) bool {
+  if prev.IsNil() || next.IsNil() {
+    return prev.IsNil() == next.IsNil()
+  }
+  return reflect.DeepEqual(prev.Any(), next.Any())
+}
@@ -0,0 +1,73 @@
+package configtest
+
+import (
+  "context"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+const fixture = `
+custom:
+  outbox_workers_count:
+    group: "outbox"
+    type: "int"
+    value: "5"
+    description: "number of outbox workers"
+  outbox_interval:
+    group: "outbox"
+    type: "duration"
+    value: "10s"
+    description: "outbox polling interval"
+`
+
+func Test_Client(t *testing.T) {
+  c := MustFromYAML(t, fixture)
+  ctx := c.Context(context.Background())
+
+  client := config.ContextClient(ctx)
+  assert.Equal(t, 5, client.GetValue(ctx, "outbox_workers_count").Int())
+  assert.Equal(t, 10*time.Second, client.GetValue(ctx, "outbox_interval").Duration())
+  assert.Equal(t, []string{"outbox_interval", "outbox_workers_count"}, client.GetKeys())
+
+  c.AssertRead(t, "outbox_workers_count", "outbox_interval")
+  c.ResetReads()
+
+  watchCtx, cancel := context.WithCancel(ctx)
+
+  var values []types.Value
+  client.WatchValue(watchCtx, "outbox_workers_count", func(value types.Value) {
+    values = append(values, value)
+  })
+  c.Set("outbox_workers_count", 10)
+  // Same value not delivered
+  c.Set("outbox_workers_count", 10)
+  c.Delete("outbox_workers_count")
+
+  // Current value delivered on subscribing
+  assert.Equal(t, 3, len(values))
+  assert.Equal(t, 5, values[0].Int())
+  assert.Equal(t, 10, values[1].Int())
+  assert.Equal(t, true, values[2].IsNil())
+
+  cancel()
+  c.Set("outbox_workers_count", 20)
+
+  assert.Equal(t, 3, len(values))
+  c.AssertNotRead(t, "outbox_workers_count")
+}
+
+func Test_FromYAMLInvalid(t *testing.T) {
+  _, err := FromYAML(`
+custom:
+  outbox_workers_count:
+    group: "outbox"
+    type: "int"
+    value: "five"
+    description: "number of outbox workers"
+`)
+  assert.NotEqual(t, nil, err)
+}
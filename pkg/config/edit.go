@@ -0,0 +1,98 @@
+package config
+
+import (
+  "bytes"
+  "fmt"
+  "os"
+
+  "gopkg.in/yaml.v3"
+)
+
+// valueTransform returns new value of the key and whether value changed
+type valueTransform func(name, value string) (string, bool, error)
+
+// UpdateValues sets values of the keys in the config file
+func UpdateValues(path string, values map[string]string) (int, error) {
+  return transformValues(path, func(name, value string) (string, bool, error) {
+    next, ok := values[name]
+    if !ok || next == value {
+      return value, false, nil
+    }
+    return next, true, nil
+  })
+}
+
+// transformValues rewrites values of the custom keys keeping file layout and comments
+func transformValues(path string, transform valueTransform) (int, error) {
+  info, err := os.Stat(path)
+  if err != nil {
+    return 0, fmt.Errorf("os.Stat: %w", err)
+  }
+  buf, err := os.ReadFile(path)
+  if err != nil {
+    return 0, fmt.Errorf("os.ReadFile: %w", err)
+  }
+  doc := &yaml.Node{}
+
+  if err = yaml.Unmarshal(buf, doc); err != nil {
+    return 0, fmt.Errorf("yaml.Unmarshal: %w", err)
+  }
+  if len(doc.Content) == 0 {
+    return 0, nil
+  }
+  custom := mappingValue(doc.Content[0], "custom")
+  if custom == nil {
+    return 0, nil
+  }
+  var count int
+
+  for index := 0; index+1 < len(custom.Content); index += 2 {
+    name := custom.Content[index].Value
+
+    valueNode := mappingValue(custom.Content[index+1], "value")
+    if valueNode == nil {
+      continue
+    }
+    value, changed, err := transform(name, valueNode.Value)
+    if err != nil {
+      return 0, fmt.Errorf("key %s: %w", name, err)
+    }
+    if !changed {
+      continue
+    }
+    valueNode.Value = value
+    valueNode.Style = yaml.DoubleQuotedStyle
+    count++
+  }
+  if count == 0 {
+    return 0, nil
+  }
+  out := &bytes.Buffer{}
+
+  encoder := yaml.NewEncoder(out)
+  encoder.SetIndent(2)
+
+  if err = encoder.Encode(doc); err != nil {
+    return 0, fmt.Errorf("yaml.Encode: %w", err)
+  }
+  if err = encoder.Close(); err != nil {
+    return 0, fmt.Errorf("yaml.Close: %w", err)
+  }
+  if err = os.WriteFile(path, out.Bytes(), info.Mode().Perm()); err != nil {
+    return 0, fmt.Errorf("os.WriteFile: %w", err)
+  }
+  return count, nil
+}
+
+// mappingValue returns value node of the mapping by key
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+  if node == nil || node.Kind != yaml.MappingNode {
+    return nil
+  }
+  for index := 0; index+1 < len(node.Content); index += 2 {
+    if node.Content[index].Value == key {
+      return node.Content[index+1]
+    }
+  }
+  return nil
+}
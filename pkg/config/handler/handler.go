@@ -0,0 +1,79 @@
+package handler
+
+import (
+  "encoding/json"
+  "net/http"
+
+  "github.com/go-chi/chi/v5"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+type configValue struct {
+  Key    string          `json:"key"`
+  Value  string          `json:"value"`
+  Source provider.Source `json:"source"`
+}
+
+type configSchema struct {
+  Schema *config.JSONSchema `json:"schema"`
+  Values []*configValue     `json:"values"`
+}
+
+func WithConfigHandler(router chi.Router) {
+  router.Get("/config", handleConfig)
+  router.Get("/config/schema", handleConfigSchema)
+}
+
+func handleConfig(w http.ResponseWriter, r *http.Request) {
+  writeJSON(w, collectValues(r))
+}
+
+func handleConfigSchema(w http.ResponseWriter, r *http.Request) {
+  schema, ok := config.LoadedSchema()
+  if !ok {
+    http.Error(w, "config not loaded", http.StatusNotFound)
+    return
+  }
+  writeJSON(w, &configSchema{
+    Schema: schema,
+    Values: collectValues(r),
+  })
+}
+
+func collectValues(r *http.Request) []*configValue {
+  ctx := r.Context()
+  client := config.ContextClient(ctx)
+
+  keys := client.GetKeys()
+  values := make([]*configValue, 0, len(keys))
+
+  for _, key := range keys {
+    value, source := client.GetValueSource(ctx, key)
+
+    shown := value.String()
+    if types.IsSecret(value) {
+      shown = types.SecretMask
+    }
+    values = append(values, &configValue{
+      Key:    key,
+      Value:  shown,
+      Source: source,
+    })
+  }
+  return values
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+  marshaled, err := json.Marshal(v)
+  if err != nil {
+    http.Error(w, "", http.StatusInternalServerError)
+    return
+  }
+  w.Header().Set("Content-Type", "application/json")
+
+  if _, err = w.Write(marshaled); err != nil {
+    http.Error(w, "", http.StatusInternalServerError)
+  }
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+  "sync"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema subset of the JSON Schema used for the config file
+type JSONSchema struct {
+  Schema               string                 `json:"$schema,omitempty"`
+  Title                string                 `json:"title,omitempty"`
+  Description          string                 `json:"description,omitempty"`
+  Type                 string                 `json:"type,omitempty"`
+  Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+  Required             []string               `json:"required,omitempty"`
+  AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+  Const                string                 `json:"const,omitempty"`
+  Enum                 []string               `json:"enum,omitempty"`
+  Pattern              string                 `json:"pattern,omitempty"`
+  Default              string                 `json:"default,omitempty"`
+}
+
+// Patterns of the raw values by types
+var valuePatterns = map[string]string{
+  "int":      `^-?\d+$`,
+  "int32":    `^-?\d+$`,
+  "int64":    `^-?\d+$`,
+  "uint32":   `^\d+$`,
+  "uint64":   `^\d+$`,
+  "float32":  `^-?\d+(\.\d+)?$`,
+  "float64":  `^-?\d+(\.\d+)?$`,
+  "duration": `^(-?\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+$`,
+}
+
+// JSONSchema returns schema of the config file with keys of the section,
+// overlay files are valid too since keys fields are not required
+func (c CustomSection) JSONSchema(app *AppSection) *JSONSchema {
+  schema := &JSONSchema{
+    Schema: jsonSchemaDraft,
+    Type:   "object",
+    Properties: map[string]*JSONSchema{
+      "version": {Type: "string"},
+      "app": {
+        Type: "object",
+        Properties: map[string]*JSONSchema{
+          "name":        {Type: "string"},
+          "version":     {Type: "string"},
+          "description": {Type: "string"},
+        },
+      },
+      "custom": c.customJSONSchema(),
+    },
+  }
+  if app != nil {
+    schema.Title = app.Name
+    schema.Description = app.Description
+  }
+  return schema
+}
+
+func (c CustomSection) customJSONSchema() *JSONSchema {
+  schema := &JSONSchema{
+    Type:                 "object",
+    Properties:           map[string]*JSONSchema{},
+    AdditionalProperties: boolPtr(false),
+  }
+  for key, val := range c {
+    schema.Properties[key.String()] = val.jsonSchema()
+  }
+  return schema
+}
+
+func (c *CustomSectionVal) jsonSchema() *JSONSchema {
+  // Value type not restricted since unquoted yaml scalars are valid values,
+  // pattern is applied to the strings only
+  value := &JSONSchema{
+    Pattern: valuePatterns[c.Type],
+  }
+  if c.Rules != nil {
+    if c.Rules.Pattern != "" && !isCollectionType(c.Type) {
+      value.Pattern = c.Rules.Pattern
+    }
+    if len(c.Rules.OneOf) > 0 && !isCollectionType(c.Type) {
+      value.Enum = c.Rules.OneOf
+    }
+  }
+  // Encrypted secrets have no defaults
+  if c.Type != secretValueType {
+    value.Default = c.Value
+  }
+  return &JSONSchema{
+    Type:        "object",
+    Description: c.Description,
+    Properties: map[string]*JSONSchema{
+      "group":       {Type: "string", Const: c.Group},
+      "type":        {Type: "string", Const: c.Type},
+      "value":       value,
+      "description": {Type: "string"},
+      "rules":       {Type: "object"},
+      "go_type":     {Type: "string"},
+    },
+    AdditionalProperties: boolPtr(false),
+  }
+}
+
+func isCollectionType(typ string) bool {
+  return typ == "[]string" || typ == "[]int" || typ == "map[string]string"
+}
+
+func boolPtr(b bool) *bool {
+  return &b
+}
+
+var (
+  loadedMu     sync.RWMutex
+  loadedParsed *Parsed
+)
+
+// LoadedSchema returns schema of the config loaded by InitClient
+func LoadedSchema() (*JSONSchema, bool) {
+  loadedMu.RLock()
+  defer loadedMu.RUnlock()
+
+  if loadedParsed == nil {
+    return nil, false
+  }
+  return loadedParsed.Custom.JSONSchema(loadedParsed.App), true
+}
+
+func setLoadedParsed(parsed *Parsed) {
+  loadedMu.Lock()
+  defer loadedMu.Unlock()
+
+  loadedParsed = parsed
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+  "testing"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+func Test_JSONSchema(t *testing.T) {
+  section := CustomSection{
+    "pg_port": {
+      Group:       "pg",
+      Type:        "int",
+      Value:       "5432",
+      Description: "postgres port",
+    },
+    "pg_mode": {
+      Group:       "pg",
+      Type:        "enum",
+      Value:       "rw",
+      Description: "postgres mode",
+      Rules:       &types.Rules{OneOf: []string{"rw", "ro"}},
+    },
+    "pg_password": {
+      Group:       "pg",
+      Type:        "secret",
+      Value:       "enc:v1:value",
+      Description: "postgres password",
+    },
+  }
+  schema := section.JSONSchema(&AppSection{Name: "app"})
+  custom := schema.Properties["custom"]
+
+  assert.Equal(t, "app", schema.Title)
+  assert.Equal(t, 3, len(custom.Properties))
+
+  port := custom.Properties["pg_port"].Properties["value"]
+  assert.Equal(t, valuePatterns["int"], port.Pattern)
+  assert.Equal(t, "5432", port.Default)
+
+  mode := custom.Properties["pg_mode"].Properties["value"]
+  assert.Equal(t, []string{"rw", "ro"}, mode.Enum)
+
+  password := custom.Properties["pg_password"].Properties["value"]
+  assert.Equal(t, "", password.Default)
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+  "fmt"
+  "os"
+  "path/filepath"
+  "strings"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+const (
+  configDir          = ".config"
+  baseConfigName     = "app_config"
+  overrideConfigName = "app_config.override"
+  configExtension    = "yaml"
+
+  // Env var prefix for config values: BOILER_CFG_<KEY>
+  EnvVarPrefix = "BOILER_CFG_"
+)
+
+type configSources map[string]provider.Source
+
+// layeredConfig config resolved from layers:
+// base file, env file, override file, key files and env vars
+type layeredConfig struct {
+  parsed  *Parsed
+  values  configValues
+  sources configSources
+}
+
+// BaseConfigPath returns path to the base config file
+func BaseConfigPath() string {
+  return filepath.Join(configDir, fmt.Sprintf("%s.%s", baseConfigName, configExtension))
+}
+
+func envConfigPath(appEnv env.Env) string {
+  name := fmt.Sprintf("%s.%s.%s", baseConfigName, strings.ToLower(appEnv.String()), configExtension)
+  return filepath.Join(configDir, name)
+}
+
+func overrideConfigPath() string {
+  return filepath.Join(configDir, fmt.Sprintf("%s.%s", overrideConfigName, configExtension))
+}
+
+// keysConfigDir returns directory with per-key files if set
+func keysConfigDir() string {
+  return env.Boiler().Config.KeysDir
+}
+
+// watchedConfigPaths returns files and directories of the config layers
+func watchedConfigPaths(basePath string) []string {
+  paths := []string{
+    basePath,
+    envConfigPath(env.AppEnv()),
+    overrideConfigPath(),
+  }
+  if dir := keysConfigDir(); dir != "" {
+    paths = append(paths, dir)
+  }
+  return paths
+}
+
+// EnvVarKey returns env var name for the config key
+func EnvVarKey(key string) string {
+  return EnvVarPrefix + strings.ToUpper(key)
+}
+
+func loadLayeredConfig(basePath string) (*layeredConfig, error) {
+  parsed, err := ParseConfig(basePath)
+  if err != nil {
+    return nil, fmt.Errorf("base config parsing failed:\n%v", err)
+  }
+  if err = parsed.Validate(); err != nil {
+    return nil, fmt.Errorf("base config validation failed:\n%v", err)
+  }
+  sources := configSources{}
+
+  for key := range parsed.Custom {
+    sources[key.String()] = provider.Source{
+      Layer:  provider.LayerBaseFile,
+      Origin: basePath,
+    }
+  }
+  overlays := []struct {
+    path  string
+    layer provider.Layer
+  }{
+    {
+      path:  envConfigPath(env.AppEnv()),
+      layer: provider.LayerEnvFile,
+    },
+    {
+      path:  overrideConfigPath(),
+      layer: provider.LayerOverrideFile,
+    },
+  }
+  for _, overlay := range overlays {
+    if !findConfig(overlay.path) {
+      continue
+    }
+    if err = mergeOverlayFile(parsed, sources, overlay.path, overlay.layer); err != nil {
+      return nil, err
+    }
+    log.Infof("config: overlay file applied: %s", overlay.path)
+  }
+  if dir := keysConfigDir(); dir != "" {
+    if err = mergeKeysDir(parsed, sources, dir); err != nil {
+      return nil, err
+    }
+  }
+  mergeEnvVars(parsed, sources)
+
+  if err = decryptSecrets(parsed, sources); err != nil {
+    return nil, err
+  }
+  values, err := collectConfigValues(parsed.Custom)
+  if err != nil {
+    return nil, fmt.Errorf("values collecting failed: %w", err)
+  }
+  return &layeredConfig{
+    parsed:  parsed,
+    values:  values,
+    sources: sources,
+  }, nil
+}
+
+// mergeOverlayFile overrides values of the base custom section,
+// overlay file contains only values of the known keys
+func mergeOverlayFile(parsed *Parsed, sources configSources, path string, layer provider.Layer) error {
+  overlay, err := ParseConfig(path)
+  if err != nil {
+    return fmt.Errorf("overlay config %s parsing failed:\n%v", path, err)
+  }
+  for key, val := range overlay.Custom {
+    baseVal, ok := parsed.Custom[key]
+    if !ok {
+      return fmt.Errorf("overlay config %s contains unknown key: %s", path, key)
+    }
+    if val == nil {
+      continue
+    }
+    if val.Type != "" && val.Type != baseVal.Type {
+      return fmt.Errorf("overlay config %s changes type of key: %s", path, key)
+    }
+    baseVal.Value = val.Value
+
+    sources[key.String()] = provider.Source{
+      Layer:  layer,
+      Origin: path,
+    }
+  }
+  return nil
+}
+
+// mergeKeysDir overrides values with contents of the files
+// named by the config keys, hidden files are skipped
+func mergeKeysDir(parsed *Parsed, sources configSources, dir string) error {
+  entries, err := os.ReadDir(dir)
+  if err != nil {
+    if os.IsNotExist(err) {
+      log.Warnf("config: keys directory not found: %s", dir)
+      return nil
+    }
+    return fmt.Errorf("keys directory %s reading failed: %v", dir, err)
+  }
+  for _, entry := range entries {
+    name := entry.Name()
+
+    if entry.IsDir() || strings.HasPrefix(name, ".") {
+      continue
+    }
+    val, ok := parsed.Custom[CustomSectionKey(name)]
+    if !ok {
+      log.Warnf("config: keys directory %s contains unknown key: %s", dir, name)
+      continue
+    }
+    path := filepath.Join(dir, name)
+
+    content, err := os.ReadFile(path)
+    if err != nil {
+      return fmt.Errorf("key file %s reading failed: %v", path, err)
+    }
+    val.Value = strings.TrimSpace(string(content))
+
+    sources[name] = provider.Source{
+      Layer:  provider.LayerKeyFile,
+      Origin: path,
+    }
+  }
+  return nil
+}
+
+func mergeEnvVars(parsed *Parsed, sources configSources) {
+  for key, val := range parsed.Custom {
+    envKey := EnvVarKey(key.String())
+
+    envVal, ok := os.LookupEnv(envKey)
+    if !ok {
+      continue
+    }
+    val.Value = envVal
+
+    sources[key.String()] = provider.Source{
+      Layer:  provider.LayerEnvVar,
+      Origin: envKey,
+    }
+  }
+}
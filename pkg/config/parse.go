@@ -23,12 +23,17 @@ func ParseConfig(configPath string) (*Parsed, error) {
   if err != nil {
     return nil, fmt.Errorf("config file read failed: %w", err)
   }
+  return ParseConfigData(configBuf)
+}
+
+// ParseConfigData parses config in the app_config.yaml format
+func ParseConfigData(configBuf []byte) (*Parsed, error) {
   parsed := &Parsed{}
 
-  if err = yaml.Unmarshal(configBuf, parsed); err != nil {
+  if err := yaml.Unmarshal(configBuf, parsed); err != nil {
     return nil, fmt.Errorf("yaml unmarshal failed: %w", err)
   }
-  return parsed, err
+  return parsed, nil
 }
 
 func collectAppInfo(as *AppSection) AppInfo {
@@ -39,13 +44,18 @@ func collectAppInfo(as *AppSection) AppInfo {
   }
 }
 
+// Values casts and validates values of the section by keys
+func (c CustomSection) Values() (map[string]types.Value, error) {
+  return collectConfigValues(c)
+}
+
 func collectConfigValues(cs CustomSection) (configValues, error) {
   values := configValues{}
 
   for csKey, csVal := range cs {
-    value, err := types.CastValue(csVal.Type, csVal.Value)
+    value, err := types.ParseValue(csVal.spec(), csVal.Value)
     if err != nil {
-      return nil, fmt.Errorf("cast config value failed: %w", err)
+      return nil, fmt.Errorf("parse config value %s failed: %w", csKey, err)
     }
     strKey := csKey.String()
 
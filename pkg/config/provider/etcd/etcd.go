@@ -3,32 +3,39 @@ package etcd
 import (
   "context"
   "fmt"
+  "sync"
   "time"
 
   "github.com/jellydator/ttlcache/v3"
   log "github.com/sirupsen/logrus"
-  "github.com/ushakovn/boiler/internal/pkg/stringer"
   "github.com/ushakovn/boiler/pkg/config/provider"
   "github.com/ushakovn/boiler/pkg/config/types"
   v3 "go.etcd.io/etcd/client/v3"
 )
 
 type etcd struct {
-  appName    string
+  // App name and shared namespaces by precedence
+  scopes     []string
+  layout     Layout
+  specs      map[string]*types.Spec
   client     *v3.Client
   cachedKeys *ttlcache.Cache[string, types.Value]
 }
 
 type config struct {
-  client   v3.Config
-  appName  string
-  cacheTTL time.Duration
+  client     v3.Config
+  tlsFiles   *tlsFiles
+  appName    string
+  layout     Layout
+  namespaces []string
+  specs      map[string]*types.Spec
+  cacheTTL   time.Duration
 }
 
 func New(calls ...Option) provider.Values {
   options := callOptions(calls...)
 
-  client, err := v3.New(options.client)
+  client, err := newClient(options)
   if err != nil {
     log.Fatalf("config: failed to create etcd values provider: %v", err)
   }
@@ -38,36 +45,146 @@ func New(calls ...Option) provider.Values {
     ),
   )
   return &etcd{
-    appName:    options.appName,
+    scopes:     append([]string{options.appName}, options.namespaces...),
+    layout:     options.layout,
+    specs:      options.specs,
     client:     client,
     cachedKeys: cache,
   }
 }
 
-func (e *etcd) Get(ctx context.Context, key string) types.Value {
-  key = e.buildKey(key)
+// NewClient creates etcd client configured by the provider options
+func NewClient(calls ...Option) (*v3.Client, error) {
+  return newClient(callOptions(calls...))
+}
 
-  if value := e.getCached(key); !value.IsNil() {
-    return value
+func newClient(options *calledOptions) (*v3.Client, error) {
+  clientConfig := options.client
+
+  if options.tlsFiles != nil {
+    tlsConfig, err := options.tlsFiles.build()
+    if err != nil {
+      return nil, fmt.Errorf("etcd tls config building failed: %w", err)
+    }
+    clientConfig.TLS = tlsConfig
   }
-  return e.get(ctx, key)
+  client, err := v3.New(clientConfig)
+  if err != nil {
+    return nil, fmt.Errorf("v3.New: %w", err)
+  }
+  return client, nil
+}
+
+func (e *etcd) Get(ctx context.Context, key string) types.Value {
+  value, _ := e.resolve(ctx, key)
+  return value
 }
 
 func (e *etcd) Watch(ctx context.Context, key string, action func(value types.Value)) {
-  key = e.buildKey(key)
+  type scopedValue struct {
+    index int
+    value types.Value
+  }
+  values := make([]types.Value, len(e.scopes))
+  updates := make(chan scopedValue)
+
+  wg := sync.WaitGroup{}
+
+  for index, scope := range e.scopes {
+    etcdKey := e.layout.Key(scope, key)
+    values[index] = e.lookup(ctx, key, etcdKey)
+
+    wg.Add(1)
+
+    go func(index int, etcdKey string) {
+      defer wg.Done()
+
+      e.watchKey(ctx, key, etcdKey, func(value types.Value) {
+        select {
+        case updates <- scopedValue{index: index, value: value}:
+        case <-ctx.Done():
+        }
+      })
+    }(index, etcdKey)
+  }
+  go func() {
+    wg.Wait()
+    close(updates)
+  }()
+
+  for update := range updates {
+    values[update.index] = update.value
+
+    // Value of the app or the first namespace containing the key
+    effective := types.NewNilValue()
+
+    for _, value := range values {
+      if !value.IsNil() {
+        effective = value
+        break
+      }
+    }
+    action(effective)
+  }
+}
 
-  ch := e.client.Watch(ctx, key)
+func (e *etcd) Source(ctx context.Context, key string) provider.Source {
+  _, etcdKey := e.resolve(ctx, key)
+
+  return provider.Source{
+    Layer:  provider.LayerEtcd,
+    Origin: etcdKey,
+  }
+}
+
+// resolve returns value of the app or the first namespace containing the key
+func (e *etcd) resolve(ctx context.Context, key string) (types.Value, string) {
+  for _, scope := range e.scopes {
+    etcdKey := e.layout.Key(scope, key)
+
+    if value := e.lookup(ctx, key, etcdKey); !value.IsNil() {
+      return value, etcdKey
+    }
+  }
+  return types.NewNilValue(), e.layout.Key(e.scopes[0], key)
+}
+
+func (e *etcd) lookup(ctx context.Context, key, etcdKey string) types.Value {
+  if value := e.getCached(etcdKey); !value.IsNil() {
+    return value
+  }
+  return e.get(ctx, key, etcdKey)
+}
+
+func (e *etcd) watchKey(ctx context.Context, key, etcdKey string, action func(value types.Value)) {
+  ch := e.client.Watch(ctx, etcdKey)
   for {
     select {
-    case resp := <-ch:
+    case resp, ok := <-ch:
+      if !ok {
+        return
+      }
+      if err := resp.Err(); err != nil {
+        log.Errorf("config: etcd watch error for key %s: %v", etcdKey, err)
+        continue
+      }
       for _, event := range resp.Events {
-        isCreateOrUpdate := event.IsCreate() || event.IsModify()
-
-        if !isCreateOrUpdate || event.Kv == nil {
+        if event.Type == v3.EventTypeDelete {
+          // Deleted value is reported as nil
+          e.cachedKeys.Delete(etcdKey)
+          action(types.NewNilValue())
+          continue
+        }
+        if event.Kv == nil {
           continue
         }
-        value := types.NewValue(string(event.Kv.Value))
-        e.cachedKeys.Set(key, value, ttlcache.DefaultTTL)
+        value, err := e.parseValue(key, string(event.Kv.Value))
+        if err != nil {
+          // Invalid live updates are not applied
+          log.Errorf("config: etcd update rejected for key %s: %v", etcdKey, err)
+          continue
+        }
+        e.cachedKeys.Set(etcdKey, value, ttlcache.DefaultTTL)
 
         action(value)
       }
@@ -77,29 +194,35 @@ func (e *etcd) Watch(ctx context.Context, key string, action func(value types.Va
   }
 }
 
-func (e *etcd) get(ctx context.Context, key string) types.Value {
-  resp, err := e.client.Get(ctx, key,
+func (e *etcd) get(ctx context.Context, key, etcdKey string) types.Value {
+  resp, err := e.client.Get(ctx, etcdKey,
     v3.WithSort(v3.SortByVersion, v3.SortDescend),
   )
   if err != nil || resp.Count == 0 || len(resp.Kvs) == 0 {
     return types.NewNilValue()
   }
   kv := resp.Kvs[len(resp.Kvs)-1]
-  value := types.NewValue(string(kv.Value))
 
-  e.cachedKeys.Set(key, value, ttlcache.DefaultTTL)
+  value, err := e.parseValue(key, string(kv.Value))
+  if err != nil {
+    log.Errorf("config: etcd value ignored for key %s: %v", etcdKey, err)
+    return types.NewNilValue()
+  }
+  e.cachedKeys.Set(etcdKey, value, ttlcache.DefaultTTL)
   return value
 }
 
+func (e *etcd) parseValue(key, rawValue string) (types.Value, error) {
+  spec, ok := e.specs[key]
+  if !ok {
+    return types.NewValue(rawValue), nil
+  }
+  return types.ParseValue(spec, rawValue)
+}
+
 func (e *etcd) getCached(key string) types.Value {
   if value := e.cachedKeys.Get(key); value != nil {
     return value.Value()
   }
   return types.NewNilValue()
 }
-
-func (e *etcd) buildKey(key string) string {
-  key = fmt.Sprintf("%s_%s", e.appName, key)
-  key = stringer.StringToSnakeCase(key)
-  return key
-}
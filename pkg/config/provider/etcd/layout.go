@@ -0,0 +1,84 @@
+package etcd
+
+import (
+  "fmt"
+  "strings"
+
+  "github.com/ushakovn/boiler/internal/pkg/stringer"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+// Layout builds etcd keys of the config keys,
+// scope is the app name or the shared namespace
+type Layout interface {
+  Key(scope, key string) string
+  Prefix(scope string) string
+}
+
+const (
+  FlatLayoutName         = "flat"
+  HierarchicalLayoutName = "hierarchical"
+)
+
+type flatLayout struct{}
+
+// FlatLayout builds keys like <scope>_<key> in snake case
+func FlatLayout() Layout {
+  return flatLayout{}
+}
+
+func (flatLayout) Key(scope, key string) string {
+  key = fmt.Sprintf("%s_%s", scope, key)
+  key = stringer.StringToSnakeCase(key)
+  return key
+}
+
+func (flatLayout) Prefix(scope string) string {
+  return stringer.StringToSnakeCase(scope) + "_"
+}
+
+// IsFlatLayout reports if the layout is flat, prefix of the flat layout
+// also matches keys of the scopes with the same name prefix
+func IsFlatLayout(layout Layout) bool {
+  _, ok := layout.(flatLayout)
+  return ok
+}
+
+type hierarchicalLayout struct {
+  root   string
+  appEnv string
+}
+
+// HierarchicalLayout builds keys like /<root>/<env>/<scope>/<key>
+func HierarchicalLayout(root string, appEnv env.Env) Layout {
+  return hierarchicalLayout{
+    root:   strings.Trim(root, "/"),
+    appEnv: strings.ToLower(appEnv.String()),
+  }
+}
+
+func (l hierarchicalLayout) Key(scope, key string) string {
+  return l.Prefix(scope) + key
+}
+
+func (l hierarchicalLayout) Prefix(scope string) string {
+  return fmt.Sprintf("/%s/%s/%s/", l.root, l.appEnv, scope)
+}
+
+// ParseLayout returns layout by name
+func ParseLayout(name, root string, appEnv env.Env) (Layout, error) {
+  switch name {
+  case FlatLayoutName:
+    return FlatLayout(), nil
+  case HierarchicalLayoutName:
+    return HierarchicalLayout(root, appEnv), nil
+  }
+  return nil, fmt.Errorf("unknown etcd layout: %s", name)
+}
+
+// EnvLayout returns layout specified by env for the app env
+func EnvLayout(appEnv env.Env) (Layout, error) {
+  settings := env.Boiler().Etcd
+
+  return ParseLayout(settings.Layout, settings.LayoutRoot, appEnv)
+}
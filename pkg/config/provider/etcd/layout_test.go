@@ -0,0 +1,41 @@
+package etcd
+
+import (
+  "testing"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+func Test_Layouts(t *testing.T) {
+  tests := []struct {
+    name   string
+    layout Layout
+    key    string
+    prefix string
+  }{
+    {
+      name:   "flat",
+      layout: FlatLayout(),
+      key:    "app_pg_host",
+      prefix: "app_",
+    },
+    {
+      name:   "hierarchical",
+      layout: HierarchicalLayout("/boiler/", env.ProductionEnv),
+      key:    "/boiler/production/app/pg_host",
+      prefix: "/boiler/production/app/",
+    },
+  }
+  for _, test := range tests {
+    t.Run(test.name, func(t *testing.T) {
+      assert.Equal(t, test.key, test.layout.Key("app", "pg_host"))
+      assert.Equal(t, test.prefix, test.layout.Prefix("app"))
+    })
+  }
+  assert.Equal(t, true, IsFlatLayout(FlatLayout()))
+  assert.Equal(t, false, IsFlatLayout(HierarchicalLayout("boiler", env.LocalEnv)))
+
+  _, err := ParseLayout("nested", "boiler", env.LocalEnv)
+  assert.NotEqual(t, nil, err)
+}
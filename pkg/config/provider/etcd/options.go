@@ -1,8 +1,11 @@
 package etcd
 
 import (
+  "crypto/tls"
   "time"
 
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config/types"
   "github.com/ushakovn/boiler/pkg/env"
   v3 "go.etcd.io/etcd/client/v3"
 )
@@ -20,29 +23,102 @@ func WithAppName(appName string) Option {
   }
 }
 
+// WithSpecs sets specs for casting and validation of the values by keys
+func WithSpecs(specs map[string]*types.Spec) Option {
+  return func(o *calledOptions) {
+    o.specs = specs
+  }
+}
+
 func WithCacheTTL(ttl time.Duration) Option {
   return func(o *calledOptions) {
     o.config.cacheTTL = ttl
   }
 }
 
+// WithEndpoints sets etcd endpoints instead of endpoints from env
+func WithEndpoints(endpoints ...string) Option {
+  return func(o *calledOptions) {
+    o.config.client.Endpoints = endpoints
+  }
+}
+
+// WithLayout sets layout of the etcd keys
+func WithLayout(layout Layout) Option {
+  return func(o *calledOptions) {
+    o.layout = layout
+  }
+}
+
+// WithNamespaces sets shared namespaces, values missing for the app
+// are looked up in the namespaces in the specified order
+func WithNamespaces(namespaces ...string) Option {
+  return func(o *calledOptions) {
+    o.namespaces = namespaces
+  }
+}
+
+// WithCredentials sets username and password of the etcd user
+func WithCredentials(username, password string) Option {
+  return func(o *calledOptions) {
+    o.config.client.Username = username
+    o.config.client.Password = password
+  }
+}
+
+// WithTLSFiles sets files of the CA, client certificate and key,
+// certificate and key are optional for server only verification
+func WithTLSFiles(caFile, certFile, keyFile string) Option {
+  return func(o *calledOptions) {
+    o.tlsFiles = &tlsFiles{
+      caFile:   caFile,
+      certFile: certFile,
+      keyFile:  keyFile,
+    }
+  }
+}
+
+// WithTLSConfig sets TLS config of the etcd client
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+  return func(o *calledOptions) {
+    o.config.client.TLS = tlsConfig
+    o.tlsFiles = nil
+  }
+}
+
 func WithDefaultConfig() Option {
   const appName = "boiler"
 
-  endpoints := env.Get(env.EtcdEndpointsKey).
-    OrDefault(env.EtcdEndpointsDefault).
-    String()
+  settings := env.Boiler()
 
+  layout, err := ParseLayout(settings.Etcd.Layout, settings.Etcd.LayoutRoot, settings.AppEnv)
+  if err != nil {
+    log.Errorf("config: %v: flat etcd layout used", err)
+    layout = FlatLayout()
+  }
+  var files *tlsFiles
+
+  if caFile := settings.Etcd.TLSCAFile; caFile != "" {
+    files = &tlsFiles{
+      caFile:   caFile,
+      certFile: settings.Etcd.TLSCertFile,
+      keyFile:  settings.Etcd.TLSKeyFile,
+    }
+  }
   return func(o *calledOptions) {
     o.config = config{
       // Etcd client config
       client: v3.Config{
-        Username:  appName,
-        Endpoints: []string{endpoints},
+        Username:  settings.Etcd.Username,
+        Password:  settings.Etcd.Password,
+        Endpoints: settings.Etcd.Endpoints,
       },
+      tlsFiles: files,
       // Values provider config
-      appName:  appName,
-      cacheTTL: 15 * time.Second,
+      appName:    appName,
+      layout:     layout,
+      namespaces: settings.Etcd.Namespaces,
+      cacheTTL:   15 * time.Second,
     }
   }
 }
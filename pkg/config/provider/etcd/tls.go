@@ -0,0 +1,40 @@
+package etcd
+
+import (
+  "crypto/tls"
+  "crypto/x509"
+  "fmt"
+  "os"
+)
+
+type tlsFiles struct {
+  caFile   string
+  certFile string
+  keyFile  string
+}
+
+func (f *tlsFiles) build() (*tls.Config, error) {
+  caBuf, err := os.ReadFile(f.caFile)
+  if err != nil {
+    return nil, fmt.Errorf("os.ReadFile: %w", err)
+  }
+  pool := x509.NewCertPool()
+
+  if !pool.AppendCertsFromPEM(caBuf) {
+    return nil, fmt.Errorf("no certificates found in CA file: %s", f.caFile)
+  }
+  tlsConfig := &tls.Config{
+    RootCAs:    pool,
+    MinVersion: tls.VersionTLS12,
+  }
+  if f.certFile == "" && f.keyFile == "" {
+    return tlsConfig, nil
+  }
+  cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+  if err != nil {
+    return nil, fmt.Errorf("tls.LoadX509KeyPair: %w", err)
+  }
+  tlsConfig.Certificates = []tls.Certificate{cert}
+
+  return tlsConfig, nil
+}
@@ -0,0 +1,215 @@
+package file
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "path/filepath"
+  "reflect"
+  "sort"
+  "strings"
+  "sync"
+  "time"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+// Snapshot values loaded from the watched files
+type Snapshot struct {
+  Values  map[string]types.Value
+  Sources map[string]provider.Source
+}
+
+// Loader loads and validates the snapshot from the watched files
+type Loader func() (*Snapshot, error)
+
+type file struct {
+  loader   Loader
+  paths    []string
+  interval time.Duration
+
+  mu          sync.RWMutex
+  snapshot    *Snapshot
+  fingerprint string
+  subscribers map[string]map[uint64]func(types.Value)
+  nextID      uint64
+}
+
+// New creates provider with initial snapshot which reloads
+// the snapshot with loader when the watched paths changed
+func New(snapshot *Snapshot, loader Loader, calls ...Option) provider.Values {
+  options := callOptions(calls...)
+
+  f := &file{
+    loader:      loader,
+    paths:       options.paths,
+    interval:    options.interval,
+    snapshot:    snapshot,
+    subscribers: map[string]map[uint64]func(types.Value){},
+  }
+  f.fingerprint = f.buildFingerprint()
+
+  go f.poll()
+
+  return f
+}
+
+func (f *file) Get(_ context.Context, key string) types.Value {
+  f.mu.RLock()
+  defer f.mu.RUnlock()
+
+  if value, ok := f.snapshot.Values[key]; ok {
+    return value
+  }
+  return types.NewNilValue()
+}
+
+func (f *file) Watch(ctx context.Context, key string, action func(value types.Value)) {
+  f.mu.Lock()
+
+  id := f.nextID
+  f.nextID++
+
+  if f.subscribers[key] == nil {
+    f.subscribers[key] = map[uint64]func(types.Value){}
+  }
+  f.subscribers[key][id] = action
+
+  f.mu.Unlock()
+
+  <-ctx.Done()
+
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  delete(f.subscribers[key], id)
+
+  if len(f.subscribers[key]) == 0 {
+    delete(f.subscribers, key)
+  }
+}
+
+func (f *file) Source(_ context.Context, key string) provider.Source {
+  f.mu.RLock()
+  defer f.mu.RUnlock()
+
+  if source, ok := f.snapshot.Sources[key]; ok {
+    return source
+  }
+  return provider.Source{Layer: provider.LayerUnknown}
+}
+
+func (f *file) poll() {
+  ticker := time.NewTicker(f.interval)
+  defer ticker.Stop()
+
+  for range ticker.C {
+    fingerprint := f.buildFingerprint()
+
+    if fingerprint == f.fingerprint {
+      continue
+    }
+    f.fingerprint = fingerprint
+    f.reload()
+  }
+}
+
+func (f *file) reload() {
+  snapshot, err := f.loader()
+  if err != nil {
+    // Last good snapshot stays in effect
+    log.Errorf("config: file reload failed, last good snapshot kept:\n%v", err)
+    return
+  }
+  f.mu.Lock()
+
+  prev := f.snapshot
+  f.snapshot = snapshot
+
+  type change struct {
+    value   types.Value
+    actions []func(types.Value)
+  }
+  var changes []change
+
+  for key, subscribers := range f.subscribers {
+    next, ok := snapshot.Values[key]
+    if !ok {
+      next = types.NewNilValue()
+    }
+    if equalValues(prev.Values[key], next) {
+      continue
+    }
+    actions := make([]func(types.Value), 0, len(subscribers))
+
+    for _, action := range subscribers {
+      actions = append(actions, action)
+    }
+    changes = append(changes, change{
+      value:   next,
+      actions: actions,
+    })
+  }
+  f.mu.Unlock()
+
+  log.Infof("config: file snapshot reloaded")
+
+  for _, c := range changes {
+    for _, action := range c.actions {
+      action(c.value)
+    }
+  }
+}
+
+// buildFingerprint describes modification state of the watched files,
+// files inside directories are watched except hidden ones
+func (f *file) buildFingerprint() string {
+  parts := make([]string, 0, len(f.paths))
+
+  for _, path := range f.paths {
+    info, err := os.Stat(path)
+    if err != nil {
+      parts = append(parts, fmt.Sprintf("%s:missing", path))
+      continue
+    }
+    if !info.IsDir() {
+      parts = append(parts, fileFingerprint(path, info))
+      continue
+    }
+    entries, err := os.ReadDir(path)
+    if err != nil {
+      parts = append(parts, fmt.Sprintf("%s:unreadable", path))
+      continue
+    }
+    for _, entry := range entries {
+      if strings.HasPrefix(entry.Name(), ".") {
+        continue
+      }
+      entryPath := filepath.Join(path, entry.Name())
+
+      // Follow symlinks like mounted config maps
+      if entryInfo, err := os.Stat(entryPath); err == nil && !entryInfo.IsDir() {
+        parts = append(parts, fileFingerprint(entryPath, entryInfo))
+      }
+    }
+  }
+  sort.Strings(parts)
+
+  return strings.Join(parts, ";")
+}
+
+func fileFingerprint(path string, info os.FileInfo) string {
+  return fmt.Sprintf("%s:%d:%d", path, info.ModTime().UnixNano(), info.Size())
+}
+
+func equalValues(prev, next types.Value) bool {
+  prevNil := prev == nil || prev.IsNil()
+  nextNil := next == nil || next.IsNil()
+
+  if prevNil || nextNil {
+    return prevNil == nextNil
+  }
+  return reflect.DeepEqual(prev.Any(), next.Any())
+}
@@ -0,0 +1,95 @@
+package file
+
+import (
+  "context"
+  "fmt"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+var writes int64
+
+func Test_FileReload(t *testing.T) {
+  path := filepath.Join(t.TempDir(), "key")
+  writeFile(t, path, "first")
+
+  loader := func() (*Snapshot, error) {
+    content, err := os.ReadFile(path)
+    if err != nil {
+      return nil, err
+    }
+    value := strings.TrimSpace(string(content))
+
+    if value == "invalid" {
+      return nil, fmt.Errorf("invalid value")
+    }
+    if value == "" {
+      return &Snapshot{}, nil
+    }
+    return &Snapshot{
+      Values:  map[string]types.Value{"key": types.NewValue(value)},
+      Sources: map[string]provider.Source{"key": {Layer: provider.LayerKeyFile, Origin: path}},
+    }, nil
+  }
+  snapshot, err := loader()
+  assert.Equal(t, nil, err)
+
+  f := New(snapshot, loader, WithPaths(path), WithInterval(5*time.Millisecond))
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  values := make(chan types.Value, 10)
+  go f.Watch(ctx, "key", func(value types.Value) {
+    values <- value
+  })
+  waitSubscribers(t, f.(*file))
+
+  writeFile(t, path, "second")
+  assert.Equal(t, "second", (<-values).String())
+
+  // Last good snapshot kept
+  writeFile(t, path, "invalid")
+  time.Sleep(50 * time.Millisecond)
+  assert.Equal(t, "second", f.Get(ctx, "key").String())
+
+  // Deleted key
+  writeFile(t, path, "")
+  assert.Equal(t, true, (<-values).IsNil())
+}
+
+func writeFile(t *testing.T, path, content string) {
+  if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+    t.Fatalf("write failed: %v", err)
+  }
+  // Modification time changes even for fast writes
+  writes++
+  stamp := time.Unix(writes, 0)
+
+  if err := os.Chtimes(path, stamp, stamp); err != nil {
+    t.Fatalf("chtimes failed: %v", err)
+  }
+}
+
+func waitSubscribers(t *testing.T, f *file) {
+  deadline := time.Now().Add(time.Second)
+
+  for time.Now().Before(deadline) {
+    f.mu.RLock()
+    count := len(f.subscribers)
+    f.mu.RUnlock()
+
+    if count > 0 {
+      return
+    }
+    time.Sleep(time.Millisecond)
+  }
+  t.Fatalf("watch not started")
+}
@@ -0,0 +1,51 @@
+package file
+
+import "time"
+
+type Option func(*calledOptions)
+
+type calledOptions struct {
+  // Embed config
+  config
+}
+
+type config struct {
+  paths    []string
+  interval time.Duration
+}
+
+// WithPaths sets watched files and directories
+func WithPaths(paths ...string) Option {
+  return func(o *calledOptions) {
+    o.paths = append(o.paths, paths...)
+  }
+}
+
+// WithInterval sets period of the watched paths checks
+func WithInterval(interval time.Duration) Option {
+  return func(o *calledOptions) {
+    o.interval = interval
+  }
+}
+
+func WithDefaultConfig() Option {
+  return func(o *calledOptions) {
+    o.config = config{
+      interval: 5 * time.Second,
+    }
+  }
+}
+
+func callOptions(calls ...Option) *calledOptions {
+  calls = append(defaultOptions(), calls...)
+  o := new(calledOptions)
+
+  for _, call := range calls {
+    call(o)
+  }
+  return o
+}
+
+func defaultOptions() []Option {
+  return []Option{WithDefaultConfig()}
+}
@@ -10,3 +10,27 @@ type Values interface {
   Get(ctx context.Context, key string) types.Value
   Watch(ctx context.Context, key string, action func(value types.Value))
 }
+
+// Sourcer implemented by providers which can tell where a value came from
+type Sourcer interface {
+  Source(ctx context.Context, key string) Source
+}
+
+type Layer string
+
+const (
+  LayerUnknown      Layer = "unknown"
+  LayerBaseFile     Layer = "base_file"
+  LayerEnvFile      Layer = "env_file"
+  LayerOverrideFile Layer = "override_file"
+  LayerKeyFile      Layer = "key_file"
+  LayerEnvVar       Layer = "env_var"
+  LayerEtcd         Layer = "etcd"
+)
+
+// Source of the resolved value:
+// layer and origin like file path, env var name or etcd key
+type Source struct {
+  Layer  Layer  `json:"layer"`
+  Origin string `json:"origin"`
+}
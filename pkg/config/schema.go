@@ -3,6 +3,8 @@ package config
 import (
   "errors"
   "fmt"
+  "regexp"
+  "sort"
   "strings"
 
   "github.com/ushakovn/boiler/internal/pkg/builder"
@@ -27,10 +29,14 @@ type Parsed struct {
 type CustomSectionKey string
 
 type CustomSectionVal struct {
-  Group       string `yaml:"group"`
-  Type        string `yaml:"type"`
-  Value       string `yaml:"value"`
-  Description string `yaml:"description"`
+  Group       string       `yaml:"group"`
+  Type        string       `yaml:"type"`
+  Value       string       `yaml:"value"`
+  Description string       `yaml:"description"`
+  Rules       *types.Rules `yaml:"rules"`
+  // Go type of the json value with import path like
+  // github.com/acme/app/internal/dto.Limits, raw json if not specified
+  GoType      string       `yaml:"go_type"`
 }
 
 type CustomSection map[CustomSectionKey]*CustomSectionVal
@@ -93,6 +99,35 @@ func (c CustomSection) Validate() error {
   return validator.Validate(fs...)
 }
 
+// keys returns sorted keys of the section
+func (c CustomSection) keys() []string {
+  keys := make([]string, 0, len(c))
+
+  for key := range c {
+    keys = append(keys, key.String())
+  }
+  sort.Strings(keys)
+
+  return keys
+}
+
+// specs returns value specs by keys of the section
+func (c CustomSection) specs() map[string]*types.Spec {
+  specs := make(map[string]*types.Spec, len(c))
+
+  for key, val := range c {
+    specs[key.String()] = val.spec()
+  }
+  return specs
+}
+
+func (c *CustomSectionVal) spec() *types.Spec {
+  return &types.Spec{
+    Type:  c.Type,
+    Rules: c.Rules,
+  }
+}
+
 func (c CustomSectionKey) Validate() error {
   if stringer.IsWrongCase(c.String()) {
     return fmt.Errorf("invalid case for key: %s\n", c)
@@ -113,9 +148,18 @@ func (c *CustomSectionVal) Validate(sectionKey string) func() error {
     if !types.IsValid(c.Type) && c.Type != "" {
       b.Write("\tinvalid value type: %s\n", c.Type)
     }
+    if err := c.Rules.Check(c.Type); err != nil && types.IsValid(c.Type) {
+      b.Write("\tinvalid rules: %v\n", err)
+    }
     if c.Description == "" {
       b.Write("\tdescription not specified\n")
     }
+    if c.GoType != "" && c.Type != jsonValueType {
+      b.Write("\tgo_type specified for not json type: %s\n", c.Type)
+    }
+    if c.GoType != "" && !regexGoType.MatchString(c.GoType) {
+      b.Write("\tinvalid go_type: %s\n", c.GoType)
+    }
     if !strings.HasPrefix(sectionKey, c.Group) {
       b.Write("\tnot contains group prefix: %s\n", c.Group)
     }
@@ -126,6 +170,11 @@ func (c *CustomSectionVal) Validate(sectionKey string) func() error {
   }
 }
 
+const jsonValueType = "json"
+
+// regexGoType matches exported type name qualified with import path
+var regexGoType = regexp.MustCompile(`^[\w.\-]+(/[\w.\-]+)*\.[A-Z]\w*$`)
+
 func (c CustomSectionKey) String() string {
   return string(c)
 }
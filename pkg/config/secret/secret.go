@@ -0,0 +1,142 @@
+package secret
+
+import (
+  "crypto/aes"
+  "crypto/cipher"
+  "crypto/rand"
+  "encoding/base64"
+  "fmt"
+  "io"
+  "os"
+  "path/filepath"
+  "strings"
+
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+// KeySize size of the AES-256 key
+const KeySize = 32
+
+// Encrypted values format: enc:v1:<base64 of nonce and ciphertext>
+const encryptedPrefix = "enc:v1:"
+
+// IsEncrypted checks value encrypted by Encrypt
+func IsEncrypted(value string) bool {
+  return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Encrypt encrypts plaintext of the config key, encrypted
+// value can be decrypted only for the same config key
+func Encrypt(key []byte, name, plaintext string) (string, error) {
+  aead, err := newAEAD(key)
+  if err != nil {
+    return "", err
+  }
+  nonce := make([]byte, aead.NonceSize())
+
+  if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+    return "", fmt.Errorf("rand.Read: %w", err)
+  }
+  sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(name))
+
+  return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts value of the config key encrypted by Encrypt
+func Decrypt(key []byte, name, value string) (string, error) {
+  if !IsEncrypted(value) {
+    return "", fmt.Errorf("value not encrypted")
+  }
+  aead, err := newAEAD(key)
+  if err != nil {
+    return "", err
+  }
+  sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+  if err != nil {
+    return "", fmt.Errorf("base64.DecodeString: %w", err)
+  }
+  if len(sealed) < aead.NonceSize() {
+    return "", fmt.Errorf("encrypted value too short")
+  }
+  nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+  plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(name))
+  if err != nil {
+    // Error not contains any value details
+    return "", fmt.Errorf("decryption failed: wrong key or corrupted value")
+  }
+  return string(plaintext), nil
+}
+
+// GenerateKey generates random key
+func GenerateKey() ([]byte, error) {
+  key := make([]byte, KeySize)
+
+  if _, err := io.ReadFull(rand.Reader, key); err != nil {
+    return nil, fmt.Errorf("rand.Read: %w", err)
+  }
+  return key, nil
+}
+
+// KeyPath returns path to the key file
+func KeyPath() string {
+  return env.Boiler().Config.SecretKeyPath
+}
+
+// LoadKey loads key from the env var or from the key file
+func LoadKey() ([]byte, error) {
+  if encoded := env.Boiler().Config.SecretKey; encoded != "" {
+    key, err := decodeKey(encoded)
+    if err != nil {
+      return nil, fmt.Errorf("env BOILER_CONFIG_SECRET_KEY invalid: %w", err)
+    }
+    return key, nil
+  }
+  path := KeyPath()
+
+  buf, err := os.ReadFile(path)
+  if err != nil {
+    return nil, fmt.Errorf("key not found in env BOILER_CONFIG_SECRET_KEY and key file %s: %w", path, err)
+  }
+  key, err := decodeKey(string(buf))
+  if err != nil {
+    return nil, fmt.Errorf("key file %s invalid: %w", path, err)
+  }
+  return key, nil
+}
+
+// WriteKey writes key file readable only by owner
+func WriteKey(path string, key []byte) error {
+  if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+    return fmt.Errorf("os.MkdirAll: %w", err)
+  }
+  encoded := base64.StdEncoding.EncodeToString(key) + "\n"
+
+  if err := os.WriteFile(path, []byte(encoded), 0o600); err != nil {
+    return fmt.Errorf("os.WriteFile: %w", err)
+  }
+  return nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+  key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+  if err != nil {
+    return nil, fmt.Errorf("base64.DecodeString: %w", err)
+  }
+  if len(key) != KeySize {
+    return nil, fmt.Errorf("key size must be %d bytes", KeySize)
+  }
+  return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+  block, err := aes.NewCipher(key)
+  if err != nil {
+    return nil, fmt.Errorf("aes.NewCipher: %w", err)
+  }
+  aead, err := cipher.NewGCM(block)
+  if err != nil {
+    return nil, fmt.Errorf("cipher.NewGCM: %w", err)
+  }
+  return aead, nil
+}
@@ -0,0 +1,28 @@
+package secret
+
+import (
+  "testing"
+
+  "github.com/go-playground/assert/v2"
+)
+
+func Test_EncryptDecrypt(t *testing.T) {
+  key, err := GenerateKey()
+  assert.Equal(t, nil, err)
+
+  encrypted, err := Encrypt(key, "pg_password", "hunter2")
+  assert.Equal(t, nil, err)
+  assert.Equal(t, true, IsEncrypted(encrypted))
+
+  decrypted, err := Decrypt(key, "pg_password", encrypted)
+  assert.Equal(t, nil, err)
+  assert.Equal(t, "hunter2", decrypted)
+
+  // Value bound to the key name
+  _, err = Decrypt(key, "pg_user", encrypted)
+  assert.NotEqual(t, nil, err)
+
+  otherKey, _ := GenerateKey()
+  _, err = Decrypt(otherKey, "pg_password", encrypted)
+  assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+  "fmt"
+
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/secret"
+)
+
+const secretValueType = "secret"
+
+// Layers of the committed files which cannot contain plaintext secrets
+var committedLayers = map[provider.Layer]struct{}{
+  provider.LayerBaseFile:     {},
+  provider.LayerEnvFile:      {},
+  provider.LayerOverrideFile: {},
+}
+
+// decryptSecrets decrypts values of the secret keys,
+// key is loaded only when encrypted values found
+func decryptSecrets(parsed *Parsed, sources configSources) error {
+  var key []byte
+
+  for csKey, val := range parsed.Custom {
+    if val.Type != secretValueType {
+      continue
+    }
+    name := csKey.String()
+
+    if !secret.IsEncrypted(val.Value) {
+      source := sources[name]
+
+      if _, ok := committedLayers[source.Layer]; ok && val.Value != "" {
+        return fmt.Errorf("secret key %s stored in plaintext in %s: use boiler config encrypt", name, source.Origin)
+      }
+      continue
+    }
+    if key == nil {
+      loaded, err := secret.LoadKey()
+      if err != nil {
+        return fmt.Errorf("secret key loading failed: %w", err)
+      }
+      key = loaded
+    }
+    decrypted, err := secret.Decrypt(key, name, val.Value)
+    if err != nil {
+      return fmt.Errorf("secret key %s: %w", name, err)
+    }
+    val.Value = decrypted
+  }
+  return nil
+}
+
+// EncryptSecrets encrypts plaintext values of the secret keys in the config file,
+// types of the keys are taken from the base config file
+func EncryptSecrets(path, basePath string, key []byte) (int, error) {
+  return transformSecrets(path, basePath, func(name, value string) (string, bool, error) {
+    if value == "" || secret.IsEncrypted(value) {
+      return value, false, nil
+    }
+    encrypted, err := secret.Encrypt(key, name, value)
+    if err != nil {
+      return "", false, err
+    }
+    return encrypted, true, nil
+  })
+}
+
+// DecryptSecrets decrypts values of the secret keys in the config file for editing
+func DecryptSecrets(path, basePath string, key []byte) (int, error) {
+  return transformSecrets(path, basePath, func(name, value string) (string, bool, error) {
+    if !secret.IsEncrypted(value) {
+      return value, false, nil
+    }
+    decrypted, err := secret.Decrypt(key, name, value)
+    if err != nil {
+      return "", false, err
+    }
+    return decrypted, true, nil
+  })
+}
+
+// transformSecrets transforms values of the keys typed as secret in the base config file
+func transformSecrets(path, basePath string, transform valueTransform) (int, error) {
+  base, err := ParseConfig(basePath)
+  if err != nil {
+    return 0, fmt.Errorf("base config parsing failed: %w", err)
+  }
+  return transformValues(path, func(name, value string) (string, bool, error) {
+    baseVal, ok := base.Custom[CustomSectionKey(name)]
+    if !ok || baseVal.Type != secretValueType {
+      return value, false, nil
+    }
+    return transform(name, value)
+  })
+}
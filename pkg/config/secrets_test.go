@@ -0,0 +1,71 @@
+package config
+
+import (
+  "encoding/base64"
+  "fmt"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/ushakovn/boiler/pkg/config/secret"
+  "github.com/ushakovn/boiler/pkg/config/types"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+const secretConfig = `app:
+  name: "app"
+  version: "v0.0.1"
+  description: "app description"
+custom:
+  pg_password:
+    group: "pg"
+    type: "secret"
+    value: "%s"
+    description: "postgres password"
+    rules:
+      min: 10
+`
+
+func Test_SecretsLoading(t *testing.T) {
+  key, err := secret.GenerateKey()
+  assert.Equal(t, nil, err)
+  t.Setenv("BOILER_CONFIG_SECRET_KEY", base64.StdEncoding.EncodeToString(key))
+  env.Reset()
+  t.Cleanup(env.Reset)
+
+  path := filepath.Join(t.TempDir(), "app_config.yaml")
+
+  // Plaintext rejected in committed files
+  writeConfig(t, path, "long enough password")
+  _, err = loadLayeredConfig(path)
+  assert.NotEqual(t, nil, err)
+
+  count, err := EncryptSecrets(path, path, key)
+  assert.Equal(t, nil, err)
+  assert.Equal(t, 1, count)
+
+  layered, err := loadLayeredConfig(path)
+  assert.Equal(t, nil, err)
+
+  value := layered.values["pg_password"]
+  assert.Equal(t, true, types.IsSecret(value))
+  assert.Equal(t, "long enough password", value.String())
+  assert.Equal(t, types.SecretMask, fmt.Sprintf("%v", value))
+
+  // Validation errors not contain value
+  writeConfig(t, path, "short")
+  _, err = EncryptSecrets(path, path, key)
+  assert.Equal(t, nil, err)
+
+  _, err = loadLayeredConfig(path)
+  assert.NotEqual(t, nil, err)
+  assert.Equal(t, false, strings.Contains(err.Error(), "short"))
+}
+
+func writeConfig(t *testing.T, path, value string) {
+  if err := os.WriteFile(path, []byte(fmt.Sprintf(secretConfig, value)), 0o600); err != nil {
+    t.Fatalf("write failed: %v", err)
+  }
+}
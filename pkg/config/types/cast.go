@@ -1,7 +1,9 @@
 package types
 
 import (
+  "encoding/json"
   "fmt"
+  "strings"
 
   "github.com/spf13/cast"
 )
@@ -26,7 +28,7 @@ func CastValue(typ, rawValue string) (Value, error) {
     value, err = cast.ToUint32E(rawValue)
   case "uint64":
     value, err = cast.ToUint64E(rawValue)
-  case "string":
+  case "string", "enum":
     value, err = cast.ToStringE(rawValue)
   case "bool":
     value, err = cast.ToBoolE(rawValue)
@@ -34,8 +36,111 @@ func CastValue(typ, rawValue string) (Value, error) {
     value, err = cast.ToTimeE(rawValue)
   case "duration":
     value, err = cast.ToDurationE(rawValue)
+  case "flag":
+    // Flag spec parsed by flags package
+    value, err = cast.ToStringE(rawValue)
+  case "[]string":
+    value, err = castStringSlice(rawValue)
+  case "[]int":
+    value, err = castIntSlice(rawValue)
+  case "map[string]string":
+    value, err = castStringMap(rawValue)
+  case "json":
+    value, err = castJSON(rawValue)
+  case "secret":
+    // Secret decrypted before casting
+    return NewSecretValue(rawValue), nil
   default:
     err = fmt.Errorf("unexpected config value type: %s", typ)
   }
-  return NewValue(value), err
+  if err != nil {
+    return NewNilValue(), err
+  }
+  return NewValue(value), nil
+}
+
+// castStringSlice casts JSON array or comma separated list
+func castStringSlice(rawValue string) ([]string, error) {
+  rawValue = strings.TrimSpace(rawValue)
+  values := []string{}
+
+  if rawValue == "" {
+    return values, nil
+  }
+  if strings.HasPrefix(rawValue, "[") {
+    var list []any
+
+    if err := json.Unmarshal([]byte(rawValue), &list); err != nil {
+      return nil, fmt.Errorf("json.Unmarshal: %w", err)
+    }
+    for _, elem := range list {
+      value, err := cast.ToStringE(elem)
+      if err != nil {
+        return nil, err
+      }
+      values = append(values, value)
+    }
+    return values, nil
+  }
+  for _, part := range strings.Split(rawValue, ",") {
+    values = append(values, strings.TrimSpace(part))
+  }
+  return values, nil
+}
+
+func castIntSlice(rawValue string) ([]int, error) {
+  list, err := castStringSlice(rawValue)
+  if err != nil {
+    return nil, err
+  }
+  values := make([]int, 0, len(list))
+
+  for _, elem := range list {
+    value, err := cast.ToIntE(elem)
+    if err != nil {
+      return nil, err
+    }
+    values = append(values, value)
+  }
+  return values, nil
+}
+
+// castStringMap casts JSON object or comma separated key=value pairs
+func castStringMap(rawValue string) (map[string]string, error) {
+  rawValue = strings.TrimSpace(rawValue)
+  values := map[string]string{}
+
+  if rawValue == "" {
+    return values, nil
+  }
+  if strings.HasPrefix(rawValue, "{") {
+    var object map[string]any
+
+    if err := json.Unmarshal([]byte(rawValue), &object); err != nil {
+      return nil, fmt.Errorf("json.Unmarshal: %w", err)
+    }
+    for key, elem := range object {
+      value, err := cast.ToStringE(elem)
+      if err != nil {
+        return nil, err
+      }
+      values[key] = value
+    }
+    return values, nil
+  }
+  for _, part := range strings.Split(rawValue, ",") {
+    key, value, ok := strings.Cut(part, "=")
+    if !ok {
+      return nil, fmt.Errorf("map pair must be key=value: %s", part)
+    }
+    values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+  }
+  return values, nil
+}
+
+func castJSON(rawValue string) (json.RawMessage, error) {
+  if !json.Valid([]byte(rawValue)) {
+    return nil, fmt.Errorf("invalid json value")
+  }
+  return json.RawMessage(rawValue), nil
 }
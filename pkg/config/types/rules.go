@@ -0,0 +1,172 @@
+package types
+
+import (
+  "errors"
+  "fmt"
+  "regexp"
+  "strings"
+
+  "github.com/spf13/cast"
+  "github.com/ushakovn/boiler/internal/pkg/builder"
+)
+
+// Rules declarative validation of the config value:
+// min and max compared with numbers, durations and times,
+// with length of strings and size of collections
+type Rules struct {
+  Min     string   `yaml:"min" json:"min,omitempty"`
+  Max     string   `yaml:"max" json:"max,omitempty"`
+  Pattern string   `yaml:"pattern" json:"pattern,omitempty"`
+  OneOf   []string `yaml:"one_of" json:"one_of,omitempty"`
+}
+
+// Check checks rules are applicable for the value type
+func (r *Rules) Check(typ string) error {
+  if r == nil {
+    if typ == "enum" {
+      return fmt.Errorf("enum type requires one_of rule")
+    }
+    return nil
+  }
+  b := builder.NewBuilder()
+
+  if typ == "enum" && len(r.OneOf) == 0 {
+    b.Write("enum type requires one_of rule; ")
+  }
+  for _, bound := range []string{r.Min, r.Max} {
+    if bound == "" {
+      continue
+    }
+    if _, err := castBound(typ, bound); err != nil {
+      b.Write("invalid bound %s for type %s: %v; ", bound, typ, err)
+    }
+  }
+  if r.Pattern != "" {
+    if _, err := regexp.Compile(r.Pattern); err != nil {
+      b.Write("invalid pattern %s: %v; ", r.Pattern, err)
+    }
+  }
+  if s := b.String(); s != "" {
+    return errors.New(strings.TrimSuffix(s, "; "))
+  }
+  return nil
+}
+
+// Validate validates value casted from raw value
+func (r *Rules) Validate(typ, rawValue string, value Value) error {
+  if err := r.Check(typ); err != nil {
+    return err
+  }
+  if r == nil {
+    return nil
+  }
+  b := builder.NewBuilder()
+
+  compared := comparedValue(typ, rawValue, value)
+  if r.Min != "" {
+    if bound, _ := castBound(typ, r.Min); compared < bound {
+      b.Write("value %s less than min %s; ", shownValue(typ, rawValue), r.Min)
+    }
+  }
+  if r.Max != "" {
+    if bound, _ := castBound(typ, r.Max); compared > bound {
+      b.Write("value %s greater than max %s; ", shownValue(typ, rawValue), r.Max)
+    }
+  }
+  elems := validatedElems(typ, rawValue, value)
+
+  if r.Pattern != "" {
+    pattern := regexp.MustCompile(r.Pattern)
+
+    for _, elem := range elems {
+      if !pattern.MatchString(elem) {
+        b.Write("value %s not matches pattern %s; ", shownValue(typ, elem), r.Pattern)
+      }
+    }
+  }
+  if len(r.OneOf) > 0 {
+    oneOf := map[string]struct{}{}
+
+    for _, allowed := range r.OneOf {
+      oneOf[allowed] = struct{}{}
+    }
+    for _, elem := range elems {
+      if _, ok := oneOf[elem]; !ok {
+        b.Write("value %s not one of %s; ", shownValue(typ, elem), strings.Join(r.OneOf, ", "))
+      }
+    }
+  }
+  if s := b.String(); s != "" {
+    return errors.New(strings.TrimSuffix(s, "; "))
+  }
+  return nil
+}
+
+// castBound casts min or max bound to comparable number
+func castBound(typ, bound string) (float64, error) {
+  switch {
+  case isNumeric(typ):
+    return cast.ToFloat64E(bound)
+
+  case typ == "duration":
+    d, err := cast.ToDurationE(bound)
+    return float64(d), err
+
+  case typ == "time":
+    t, err := cast.ToTimeE(bound)
+    return float64(t.UnixNano()), err
+  }
+  // Length or size bound
+  return cast.ToFloat64E(bound)
+}
+
+func comparedValue(typ, rawValue string, value Value) float64 {
+  switch {
+  case isNumeric(typ):
+    return value.Float64()
+
+  case typ == "duration":
+    return float64(value.Duration())
+
+  case typ == "time":
+    return float64(value.Time().UnixNano())
+
+  case typ == "[]string":
+    return float64(len(value.StringSlice()))
+
+  case typ == "[]int":
+    return float64(len(value.IntSlice()))
+
+  case typ == "map[string]string":
+    return float64(len(value.StringMap()))
+  }
+  return float64(len(rawValue))
+}
+
+// validatedElems returns elements checked by pattern and one_of rules
+func validatedElems(typ, rawValue string, value Value) []string {
+  switch {
+  case typ == "[]string":
+    return value.StringSlice()
+
+  case typ == "[]int":
+    return cast.ToStringSlice(value.IntSlice())
+
+  case typ == "map[string]string":
+    elems := make([]string, 0, len(value.StringMap()))
+
+    for _, elem := range value.StringMap() {
+      elems = append(elems, elem)
+    }
+    return elems
+  }
+  return []string{rawValue}
+}
+
+// shownValue masks secrets in validation errors
+func shownValue(typ, value string) string {
+  if typ == "secret" {
+    return SecretMask
+  }
+  return value
+}
@@ -1,20 +1,60 @@
 package types
 
 var valueTypes = map[string]struct{}{
-  "int":      {},
-  "int32":    {},
-  "int64":    {},
-  "float32":  {},
-  "float64":  {},
-  "uint32":   {},
-  "uint64":   {},
-  "string":   {},
-  "bool":     {},
-  "time":     {},
-  "duration": {},
+  "int":               {},
+  "int32":             {},
+  "int64":             {},
+  "float32":           {},
+  "float64":           {},
+  "uint32":            {},
+  "uint64":            {},
+  "string":            {},
+  "bool":              {},
+  "time":              {},
+  "duration":          {},
+  "flag":              {},
+  "[]string":          {},
+  "[]int":             {},
+  "map[string]string": {},
+  "json":              {},
+  "enum":              {},
+  "secret":            {},
+}
+
+var numericTypes = map[string]struct{}{
+  "int":     {},
+  "int32":   {},
+  "int64":   {},
+  "float32": {},
+  "float64": {},
+  "uint32":  {},
+  "uint64":  {},
 }
 
 func IsValid(typ string) bool {
   _, ok := valueTypes[typ]
   return ok
 }
+
+func isNumeric(typ string) bool {
+  _, ok := numericTypes[typ]
+  return ok
+}
+
+// Spec type and validation rules of the config key
+type Spec struct {
+  Type  string
+  Rules *Rules
+}
+
+// ParseValue casts raw value to the spec type and validates it by the spec rules
+func ParseValue(spec *Spec, rawValue string) (Value, error) {
+  value, err := CastValue(spec.Type, rawValue)
+  if err != nil {
+    return nil, err
+  }
+  if err = spec.Rules.Validate(spec.Type, rawValue, value); err != nil {
+    return nil, err
+  }
+  return value, nil
+}
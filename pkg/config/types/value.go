@@ -1,6 +1,9 @@
 package types
 
 import (
+  "encoding/json"
+  "fmt"
+  "io"
   "time"
 
   "github.com/spf13/cast"
@@ -8,6 +11,7 @@ import (
 
 type Value interface {
   IsNil() bool
+  Any() any
   Int() int
   Int64() int64
   Int32() int32
@@ -19,6 +23,10 @@ type Value interface {
   Bool() bool
   Time() time.Time
   Duration() time.Duration
+  StringSlice() []string
+  IntSlice() []int
+  StringMap() map[string]string
+  JSON(dst any) error
 }
 
 type configValue struct {
@@ -33,10 +41,39 @@ func NewNilValue() Value {
   return configValue{}
 }
 
+// SecretMask replaces secret values in formatted output
+const SecretMask = "******"
+
+// secretValue config value masked when formatted by fmt,
+// plaintext available only with String method
+type secretValue struct {
+  configValue
+}
+
+func NewSecretValue(value string) Value {
+  return &secretValue{
+    configValue: configValue{value: value},
+  }
+}
+
+func (s *secretValue) Format(f fmt.State, _ rune) {
+  _, _ = io.WriteString(f, SecretMask)
+}
+
+// IsSecret checks value is secret
+func IsSecret(value Value) bool {
+  _, ok := value.(*secretValue)
+  return ok
+}
+
 func (c configValue) IsNil() bool {
   return c.value == nil
 }
 
+func (c configValue) Any() any {
+  return c.value
+}
+
 func (c configValue) Int() int {
   return cast.ToInt(c.value)
 }
@@ -80,3 +117,41 @@ func (c configValue) Time() time.Time {
 func (c configValue) Duration() time.Duration {
   return cast.ToDuration(c.value)
 }
+
+func (c configValue) StringSlice() []string {
+  return cast.ToStringSlice(c.value)
+}
+
+func (c configValue) IntSlice() []int {
+  return cast.ToIntSlice(c.value)
+}
+
+func (c configValue) StringMap() map[string]string {
+  return cast.ToStringMapString(c.value)
+}
+
+// JSON unmarshal value into dst
+func (c configValue) JSON(dst any) error {
+  var buf []byte
+
+  switch t := c.value.(type) {
+  case nil:
+    return fmt.Errorf("nil config value")
+  case json.RawMessage:
+    buf = t
+  case []byte:
+    buf = t
+  case string:
+    buf = []byte(t)
+  default:
+    marshaled, err := json.Marshal(t)
+    if err != nil {
+      return fmt.Errorf("json.Marshal: %w", err)
+    }
+    buf = marshaled
+  }
+  if err := json.Unmarshal(buf, dst); err != nil {
+    return fmt.Errorf("json.Unmarshal: %w", err)
+  }
+  return nil
+}
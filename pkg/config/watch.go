@@ -0,0 +1,231 @@
+package config
+
+import (
+  "context"
+  "reflect"
+  "sync"
+  "time"
+
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+const defaultWatchDebounce = 100 * time.Millisecond
+
+// ChangeEvent change of the effective value of the key
+type ChangeEvent struct {
+  Key      string
+  OldValue types.Value
+  NewValue types.Value
+  Source   provider.Source
+  Deleted  bool
+}
+
+// watchHub multiplexes providers watches between subscribers:
+// one watch per provider and key shared by all key subscribers
+type watchHub struct {
+  mu        sync.Mutex
+  debounce  time.Duration
+  providers []provider.Values
+  watches   map[string]*keyWatch
+}
+
+type keyWatch struct {
+  key    string
+  cancel context.CancelFunc
+
+  // Last values by providers indexes
+  values    []types.Value
+  effective types.Value
+
+  subscribers map[uint64]func(ChangeEvent)
+  nextID      uint64
+  timer       *time.Timer
+
+  // Closed when initial values are read
+  ready chan struct{}
+}
+
+func newWatchHub(debounce time.Duration, providers ...provider.Values) *watchHub {
+  return &watchHub{
+    debounce:  debounce,
+    providers: providers,
+    watches:   map[string]*keyWatch{},
+  }
+}
+
+// subscribeValue calls action with the current effective key value
+// and then with the new effective value on each change until ctx done
+func (h *watchHub) subscribeValue(ctx context.Context, key string, action func(types.Value)) {
+  // Changes are delivered after the current value
+  var mu sync.Mutex
+
+  mu.Lock()
+  defer mu.Unlock()
+
+  current := h.subscribe(ctx, key, func(event ChangeEvent) {
+    mu.Lock()
+    defer mu.Unlock()
+
+    action(event.NewValue)
+  })
+  action(current)
+}
+
+// subscribe calls action on each change of the effective key value until ctx done,
+// returns the effective key value at subscribing
+func (h *watchHub) subscribe(ctx context.Context, key string, action func(ChangeEvent)) types.Value {
+  h.mu.Lock()
+
+  w, ok := h.watches[key]
+  if !ok {
+    w = newKeyWatch(key, len(h.providers))
+    h.watches[key] = w
+  }
+  id := w.nextID
+  w.nextID++
+  w.subscribers[id] = action
+
+  h.mu.Unlock()
+
+  // Initial values are read without hub lock, providers may be remote
+  if !ok {
+    h.startWatch(w)
+  }
+  <-w.ready
+
+  go func() {
+    <-ctx.Done()
+    h.unsubscribe(key, w, id)
+  }()
+
+  h.mu.Lock()
+  defer h.mu.Unlock()
+
+  return w.effective
+}
+
+func (h *watchHub) unsubscribe(key string, w *keyWatch, id uint64) {
+  h.mu.Lock()
+  defer h.mu.Unlock()
+
+  delete(w.subscribers, id)
+
+  if len(w.subscribers) > 0 {
+    return
+  }
+  // Last subscriber gone
+  w.cancel()
+
+  if w.timer != nil {
+    w.timer.Stop()
+  }
+  if h.watches[key] == w {
+    delete(h.watches, key)
+  }
+}
+
+func newKeyWatch(key string, providers int) *keyWatch {
+  return &keyWatch{
+    key:         key,
+    values:      make([]types.Value, providers),
+    subscribers: map[uint64]func(ChangeEvent){},
+    ready:       make(chan struct{}),
+  }
+}
+
+// startWatch reads initial values and starts providers watches,
+// watch is not accessed by others and not unsubscribed until ready
+func (h *watchHub) startWatch(w *keyWatch) {
+  watchCtx, cancel := context.WithCancel(context.Background())
+  w.cancel = cancel
+
+  for index, p := range h.providers {
+    w.values[index] = p.Get(watchCtx, w.key)
+  }
+  w.effective, _ = h.resolve(watchCtx, w)
+
+  close(w.ready)
+
+  for index, p := range h.providers {
+    go func(index int, p provider.Values) {
+      p.Watch(watchCtx, w.key, func(value types.Value) {
+        h.onValue(watchCtx, w, index, value)
+      })
+    }(index, p)
+  }
+}
+
+func (h *watchHub) onValue(ctx context.Context, w *keyWatch, index int, value types.Value) {
+  h.mu.Lock()
+  defer h.mu.Unlock()
+
+  if ctx.Err() != nil {
+    return
+  }
+  w.values[index] = value
+
+  // Flush is delayed until no values come during debounce period
+  if w.timer != nil {
+    w.timer.Reset(h.debounce)
+    return
+  }
+  w.timer = time.AfterFunc(h.debounce, func() {
+    h.flush(ctx, w)
+  })
+}
+
+func (h *watchHub) flush(ctx context.Context, w *keyWatch) {
+  h.mu.Lock()
+
+  prev := w.effective
+  next, source := h.resolve(ctx, w)
+
+  if ctx.Err() != nil || equalValues(prev, next) {
+    h.mu.Unlock()
+    return
+  }
+  w.effective = next
+
+  event := ChangeEvent{
+    Key:      w.key,
+    OldValue: prev,
+    NewValue: next,
+    Source:   source,
+    Deleted:  next.IsNil(),
+  }
+  actions := make([]func(ChangeEvent), 0, len(w.subscribers))
+
+  for _, action := range w.subscribers {
+    actions = append(actions, action)
+  }
+  h.mu.Unlock()
+
+  for _, action := range actions {
+    action(event)
+  }
+}
+
+// resolve returns value of the first provider by precedence
+func (h *watchHub) resolve(ctx context.Context, w *keyWatch) (types.Value, provider.Source) {
+  for index, value := range w.values {
+    if value == nil || value.IsNil() {
+      continue
+    }
+    if sourcer, ok := h.providers[index].(provider.Sourcer); ok {
+      return value, sourcer.Source(ctx, w.key)
+    }
+    return value, provider.Source{Layer: provider.LayerUnknown}
+  }
+  return types.NewNilValue(), provider.Source{}
+}
+
+func equalValues(prev, next types.Value) bool {
+  prevNil := prev == nil || prev.IsNil()
+  nextNil := next == nil || next.IsNil()
+
+  if prevNil || nextNil {
+    return prevNil == nextNil
+  }
+  return reflect.DeepEqual(prev.Any(), next.Any())
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+  "context"
+  "sync"
+  "sync/atomic"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/ushakovn/boiler/pkg/config/provider"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+type fakeProvider struct {
+  mu      sync.Mutex
+  value   types.Value
+  layer   provider.Layer
+  watches int32
+  actions []func(types.Value)
+}
+
+func newFakeProvider(layer provider.Layer, value types.Value) *fakeProvider {
+  return &fakeProvider{
+    layer: layer,
+    value: value,
+  }
+}
+
+func (p *fakeProvider) Get(_ context.Context, _ string) types.Value {
+  p.mu.Lock()
+  defer p.mu.Unlock()
+
+  return p.value
+}
+
+func (p *fakeProvider) Watch(ctx context.Context, _ string, action func(types.Value)) {
+  atomic.AddInt32(&p.watches, 1)
+
+  p.mu.Lock()
+  p.actions = append(p.actions, action)
+  p.mu.Unlock()
+
+  <-ctx.Done()
+}
+
+func (p *fakeProvider) Source(_ context.Context, key string) provider.Source {
+  return provider.Source{Layer: p.layer, Origin: key}
+}
+
+func (p *fakeProvider) set(value types.Value) {
+  p.mu.Lock()
+  p.value = value
+  actions := p.actions
+  p.mu.Unlock()
+
+  for _, action := range actions {
+    action(value)
+  }
+}
+
+func (p *fakeProvider) waitWatches(t *testing.T, count int32) {
+  deadline := time.Now().Add(time.Second)
+
+  for atomic.LoadInt32(&p.watches) < count {
+    if time.Now().After(deadline) {
+      t.Fatalf("watches not started")
+    }
+    time.Sleep(time.Millisecond)
+  }
+}
+
+func Test_WatchHubPrecedenceAndDeletes(t *testing.T) {
+  etcd := newFakeProvider(provider.LayerEtcd, types.NewNilValue())
+  local := newFakeProvider(provider.LayerBaseFile, types.NewValue("local"))
+
+  hub := newWatchHub(10*time.Millisecond, etcd, local)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  events := make(chan ChangeEvent, 10)
+  hub.subscribe(ctx, "key", func(event ChangeEvent) {
+    events <- event
+  })
+  etcd.waitWatches(t, 1)
+  local.waitWatches(t, 1)
+
+  // Debounced to the last value
+  etcd.set(types.NewValue("first"))
+  etcd.set(types.NewValue("second"))
+
+  event := <-events
+  assert.Equal(t, "local", event.OldValue.String())
+  assert.Equal(t, "second", event.NewValue.String())
+  assert.Equal(t, provider.LayerEtcd, event.Source.Layer)
+
+  // Deleted etcd value falls back to local
+  etcd.set(types.NewNilValue())
+
+  event = <-events
+  assert.Equal(t, "local", event.NewValue.String())
+  assert.Equal(t, provider.LayerBaseFile, event.Source.Layer)
+  assert.Equal(t, false, event.Deleted)
+
+  local.set(types.NewNilValue())
+
+  event = <-events
+  assert.Equal(t, true, event.Deleted)
+}
+
+func Test_WatchHubSharedWatches(t *testing.T) {
+  etcd := newFakeProvider(provider.LayerEtcd, types.NewValue("value"))
+  hub := newWatchHub(time.Millisecond, etcd)
+
+  ctx, cancel := context.WithCancel(context.Background())
+
+  var received int32
+
+  for i := 0; i < 3; i++ {
+    hub.subscribe(ctx, "key", func(ChangeEvent) {
+      atomic.AddInt32(&received, 1)
+    })
+  }
+  etcd.waitWatches(t, 1)
+  etcd.set(types.NewValue("changed"))
+
+  deadline := time.Now().Add(time.Second)
+
+  for atomic.LoadInt32(&received) < 3 && time.Now().Before(deadline) {
+    time.Sleep(time.Millisecond)
+  }
+  assert.Equal(t, int32(3), atomic.LoadInt32(&received))
+  assert.Equal(t, int32(1), atomic.LoadInt32(&etcd.watches))
+
+  cancel()
+
+  for deadline = time.Now().Add(time.Second); time.Now().Before(deadline); {
+    hub.mu.Lock()
+    count := len(hub.watches)
+    hub.mu.Unlock()
+
+    if count == 0 {
+      return
+    }
+    time.Sleep(time.Millisecond)
+  }
+  t.Fatalf("watch not stopped after all subscribers gone")
+}
+
+func Test_WatchHubValueDeliversCurrent(t *testing.T) {
+  etcd := newFakeProvider(provider.LayerEtcd, types.NewValue("current"))
+  hub := newWatchHub(time.Millisecond, etcd)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  values := make(chan types.Value, 10)
+  hub.subscribeValue(ctx, "key", func(value types.Value) {
+    values <- value
+  })
+  assert.Equal(t, "current", (<-values).String())
+
+  etcd.waitWatches(t, 1)
+  etcd.set(types.NewValue("changed"))
+
+  assert.Equal(t, "changed", (<-values).String())
+}
+
+func Test_WatchHubDebounceRestarted(t *testing.T) {
+  etcd := newFakeProvider(provider.LayerEtcd, types.NewValue("initial"))
+  hub := newWatchHub(100*time.Millisecond, etcd)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  events := make(chan ChangeEvent, 10)
+  hub.subscribe(ctx, "key", func(event ChangeEvent) {
+    events <- event
+  })
+  etcd.waitWatches(t, 1)
+
+  // Each value delays the flush, only the last value delivered
+  for _, value := range []string{"first", "second", "third"} {
+    etcd.set(types.NewValue(value))
+    time.Sleep(60 * time.Millisecond)
+  }
+  event := <-events
+  assert.Equal(t, "initial", event.OldValue.String())
+  assert.Equal(t, "third", event.NewValue.String())
+}
+
+type blockingProvider struct {
+  *fakeProvider
+  blockedKey string
+  release    chan struct{}
+}
+
+func (p *blockingProvider) Get(ctx context.Context, key string) types.Value {
+  if key == p.blockedKey {
+    <-p.release
+  }
+  return p.fakeProvider.Get(ctx, key)
+}
+
+func Test_WatchHubInitialReadNotBlocking(t *testing.T) {
+  etcd := &blockingProvider{
+    fakeProvider: newFakeProvider(provider.LayerEtcd, types.NewValue("value")),
+    blockedKey:   "slow",
+    release:      make(chan struct{}),
+  }
+  hub := newWatchHub(time.Millisecond, etcd)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  slow := make(chan types.Value, 1)
+  go func() {
+    slow <- hub.subscribe(ctx, "slow", func(ChangeEvent) {})
+  }()
+  // Subscribing of another key is not blocked by the slow read
+  done := make(chan types.Value, 1)
+  go func() {
+    done <- hub.subscribe(ctx, "fast", func(ChangeEvent) {})
+  }()
+  select {
+  case value := <-done:
+    assert.Equal(t, "value", value.String())
+  case <-time.After(time.Second):
+    t.Fatalf("subscribing blocked by initial read of another key")
+  }
+  close(etcd.release)
+
+  assert.Equal(t, "value", (<-slow).String())
+}
@@ -1,9 +1,5 @@
 package env
 
-const (
-  AppEnvKey Key = "BOILER_APP_ENV"
-)
-
 const (
   ProductionEnv Env = "PRODUCTION"
   StagingEnv    Env = "STAGING"
@@ -17,10 +13,5 @@ var knownAppEnvs = map[Env]struct{}{
 }
 
 func AppEnv() Env {
-  env := Get(AppEnvKey)
-
-  if _, ok := knownAppEnvs[env]; ok {
-    return env
-  }
-  return LocalEnv
+  return Boiler().AppEnv
 }
@@ -2,20 +2,6 @@ package env
 
 import "os"
 
-const (
-  PrometheusEndpointKey     Key = "BOILER_PROMETHEUS_ENDPOINT"
-  PrometheusEndpointDefault Env = "localhost:9090"
-
-  GrafanaEndpointKey     Key = "BOILER_GRAFANA_ENDPOINT"
-  GrafanaEndpointDefault Env = "localhost:3000"
-
-  JaegerEndpointKey     Key = "BOILER_JAEGER_ENDPOINT"
-  JaegerEndpointDefault Env = "localhost:4318"
-
-  EtcdEndpointsKey     Key = "BOILER_ETCD_ENDPOINTS"
-  EtcdEndpointsDefault Env = "localhost:2379"
-)
-
 type (
   Key string
   Env string
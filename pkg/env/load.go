@@ -0,0 +1,166 @@
+package env
+
+import (
+  "errors"
+  "fmt"
+  "os"
+  "reflect"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/ushakovn/boiler/internal/pkg/builder"
+)
+
+const (
+  tagEnv       = "env"
+  tagDefault   = "default"
+  tagRequired  = "required"
+  tagSeparator = "separator"
+
+  defaultSeparator = ","
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load sets fields of the struct pointed by dst from env vars by field tags:
+//
+//  env:"BOILER_X"   name of the env var
+//  default:"value"  value used if env var not set or empty
+//  required:"true"  env var or default must be set
+//  separator:";"    separator of the list elements, comma by default
+//
+// Supported types are strings, bools, ints, uints, floats, durations and lists of them.
+// Nested structs without env tag are loaded recursively.
+// Invalid values keep defaults, errors of all fields are returned together
+func Load(dst any) error {
+  v := reflect.ValueOf(dst)
+
+  if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+    return fmt.Errorf("env.Load: pointer to struct expected, got %T", dst)
+  }
+  b := builder.NewBuilder()
+
+  loadStruct(&b, v.Elem())
+
+  if s := b.String(); s != "" {
+    return errors.New(strings.TrimSuffix(s, "; "))
+  }
+  return nil
+}
+
+func loadStruct(b *builder.Builder, v reflect.Value) {
+  typ := v.Type()
+
+  for i := 0; i < typ.NumField(); i++ {
+    field := typ.Field(i)
+
+    if !field.IsExported() {
+      continue
+    }
+    name, ok := field.Tag.Lookup(tagEnv)
+    if !ok {
+      if field.Type.Kind() == reflect.Struct {
+        loadStruct(b, v.Field(i))
+      }
+      continue
+    }
+    loadField(b, name, field, v.Field(i))
+  }
+}
+
+func loadField(b *builder.Builder, name string, field reflect.StructField, v reflect.Value) {
+  separator := field.Tag.Get(tagSeparator)
+  if separator == "" {
+    separator = defaultSeparator
+  }
+  defaultValue, hasDefault := field.Tag.Lookup(tagDefault)
+
+  if hasDefault {
+    parsed, err := parseValue(field.Type, defaultValue, separator)
+    if err != nil {
+      b.Write("%s: invalid default: %v; ", name, err)
+    } else {
+      v.Set(parsed)
+    }
+  }
+  raw := os.Getenv(name)
+
+  if raw == "" {
+    if !hasDefault && field.Tag.Get(tagRequired) == "true" {
+      b.Write("%s: required but not set; ", name)
+    }
+    return
+  }
+  parsed, err := parseValue(field.Type, raw, separator)
+  if err != nil {
+    b.Write("%s: %v; ", name, err)
+    return
+  }
+  v.Set(parsed)
+}
+
+func parseValue(typ reflect.Type, raw, separator string) (reflect.Value, error) {
+  v := reflect.New(typ).Elem()
+
+  if typ == durationType {
+    d, err := time.ParseDuration(raw)
+    if err != nil {
+      return v, fmt.Errorf("time.ParseDuration: %w", err)
+    }
+    v.SetInt(int64(d))
+    return v, nil
+  }
+  switch typ.Kind() {
+  case reflect.String:
+    v.SetString(raw)
+
+  case reflect.Bool:
+    b, err := strconv.ParseBool(raw)
+    if err != nil {
+      return v, fmt.Errorf("strconv.ParseBool: %w", err)
+    }
+    v.SetBool(b)
+
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    i, err := strconv.ParseInt(raw, 10, typ.Bits())
+    if err != nil {
+      return v, fmt.Errorf("strconv.ParseInt: %w", err)
+    }
+    v.SetInt(i)
+
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+    u, err := strconv.ParseUint(raw, 10, typ.Bits())
+    if err != nil {
+      return v, fmt.Errorf("strconv.ParseUint: %w", err)
+    }
+    v.SetUint(u)
+
+  case reflect.Float32, reflect.Float64:
+    f, err := strconv.ParseFloat(raw, typ.Bits())
+    if err != nil {
+      return v, fmt.Errorf("strconv.ParseFloat: %w", err)
+    }
+    v.SetFloat(f)
+
+  case reflect.Slice:
+    if typ.Elem().Kind() == reflect.Slice {
+      return v, fmt.Errorf("nested lists not supported")
+    }
+    // Empty elements are skipped
+    for _, part := range strings.Split(raw, separator) {
+      if part = strings.TrimSpace(part); part == "" {
+        continue
+      }
+      elem, err := parseValue(typ.Elem(), part, separator)
+      if err != nil {
+        return v, err
+      }
+      v = reflect.Append(v, elem)
+    }
+
+  default:
+    return v, fmt.Errorf("unsupported type %s", typ)
+  }
+  return v, nil
+}
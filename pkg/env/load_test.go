@@ -0,0 +1,113 @@
+package env
+
+import (
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+)
+
+func Test_LoadTypes(t *testing.T) {
+  type nested struct {
+    Timeout time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+  }
+  type settings struct {
+    Name    string   `env:"TEST_NAME" default:"app"`
+    Env     Env      `env:"TEST_ENV"`
+    Port    int      `env:"TEST_PORT" default:"8080"`
+    Workers uint32   `env:"TEST_WORKERS"`
+    Ratio   float64  `env:"TEST_RATIO"`
+    Debug   bool     `env:"TEST_DEBUG"`
+    Hosts   []string `env:"TEST_HOSTS"`
+    Codes   []int    `env:"TEST_CODES" separator:";"`
+    Nested  nested
+    skipped string `env:"TEST_SKIPPED"`
+  }
+  t.Setenv("TEST_ENV", "STAGING")
+  t.Setenv("TEST_PORT", "9090")
+  t.Setenv("TEST_WORKERS", "4")
+  t.Setenv("TEST_RATIO", "0.5")
+  t.Setenv("TEST_DEBUG", "true")
+  t.Setenv("TEST_HOSTS", "a:1, b:2,,")
+  t.Setenv("TEST_CODES", "1;2")
+  t.Setenv("TEST_TIMEOUT", "1m")
+  t.Setenv("TEST_SKIPPED", "value")
+
+  var s settings
+  assert.Equal(t, nil, Load(&s))
+
+  assert.Equal(t, "app", s.Name)
+  assert.Equal(t, StagingEnv, s.Env)
+  assert.Equal(t, 9090, s.Port)
+  assert.Equal(t, uint32(4), s.Workers)
+  assert.Equal(t, 0.5, s.Ratio)
+  assert.Equal(t, true, s.Debug)
+  assert.Equal(t, []string{"a:1", "b:2"}, s.Hosts)
+  assert.Equal(t, []int{1, 2}, s.Codes)
+  assert.Equal(t, time.Minute, s.Nested.Timeout)
+  assert.Equal(t, "", s.skipped)
+}
+
+func Test_LoadErrors(t *testing.T) {
+  type settings struct {
+    Port     int           `env:"TEST_PORT" default:"8080"`
+    Timeout  time.Duration `env:"TEST_TIMEOUT"`
+    Token    string        `env:"TEST_TOKEN" required:"true"`
+    Endpoint string        `env:"TEST_ENDPOINT" required:"true" default:"localhost"`
+  }
+  t.Setenv("TEST_PORT", "port")
+  t.Setenv("TEST_TIMEOUT", "5")
+
+  var s settings
+  err := Load(&s)
+  assert.NotEqual(t, nil, err)
+
+  // Errors of all fields are returned
+  msg := err.Error()
+  assert.Equal(t, true, strings.Contains(msg, "TEST_PORT"))
+  assert.Equal(t, true, strings.Contains(msg, "TEST_TIMEOUT"))
+  assert.Equal(t, true, strings.Contains(msg, "TEST_TOKEN: required but not set"))
+  assert.Equal(t, false, strings.Contains(msg, "TEST_ENDPOINT"))
+
+  // Invalid values keep defaults
+  assert.Equal(t, 8080, s.Port)
+  assert.Equal(t, "localhost", s.Endpoint)
+
+  assert.NotEqual(t, nil, Load(s))
+}
+
+func Test_LoadSettingsDefaults(t *testing.T) {
+  t.Setenv("BOILER_APP_ENV", "UNKNOWN")
+  t.Setenv("BOILER_ETCD_NAMESPACES", "shared,payments")
+
+  settings, err := LoadSettings()
+  assert.Equal(t, nil, err)
+
+  assert.Equal(t, LocalEnv, settings.AppEnv)
+  assert.Equal(t, "localhost:9090", settings.PrometheusEndpoint)
+  assert.Equal(t, "localhost:3000", settings.GrafanaEndpoint)
+  assert.Equal(t, "localhost:4318", settings.JaegerEndpoint)
+  assert.Equal(t, []string{"localhost:2379"}, settings.Etcd.Endpoints)
+  assert.Equal(t, "flat", settings.Etcd.Layout)
+  assert.Equal(t, "boiler", settings.Etcd.LayoutRoot)
+  assert.Equal(t, ".config/secret.key", settings.Config.SecretKeyPath)
+  assert.Equal(t, 8082, settings.Ports.Grpc)
+
+  assert.Equal(t, []string{"shared", "payments"}, settings.Etcd.Namespaces)
+}
+
+func Test_BoilerLoadedOnce(t *testing.T) {
+  t.Cleanup(Reset)
+
+  t.Setenv("BOILER_APP_ENV", "STAGING")
+  Reset()
+  assert.Equal(t, StagingEnv, AppEnv())
+
+  // Env changes are not read until reset
+  t.Setenv("BOILER_APP_ENV", "PRODUCTION")
+  assert.Equal(t, StagingEnv, AppEnv())
+
+  Reset()
+  assert.Equal(t, ProductionEnv, AppEnv())
+}
@@ -0,0 +1,134 @@
+package env
+
+import (
+  "sync"
+  "sync/atomic"
+
+  log "github.com/sirupsen/logrus"
+)
+
+// Env keys and defaults of the boiler settings declared before Settings tags
+const (
+  // Deprecated: use env.Boiler().AppEnv
+  AppEnvKey Key = "BOILER_APP_ENV"
+
+  // Deprecated: use env.Boiler().PrometheusEndpoint
+  PrometheusEndpointKey Key = "BOILER_PROMETHEUS_ENDPOINT"
+  // Deprecated: use env.Boiler().PrometheusEndpoint
+  PrometheusEndpointDefault Env = "localhost:9090"
+
+  // Deprecated: use env.Boiler().GrafanaEndpoint
+  GrafanaEndpointKey Key = "BOILER_GRAFANA_ENDPOINT"
+  // Deprecated: use env.Boiler().GrafanaEndpoint
+  GrafanaEndpointDefault Env = "localhost:3000"
+
+  // Deprecated: use env.Boiler().JaegerEndpoint
+  JaegerEndpointKey Key = "BOILER_JAEGER_ENDPOINT"
+  // Deprecated: use env.Boiler().JaegerEndpoint
+  JaegerEndpointDefault Env = "localhost:4318"
+
+  // Deprecated: use env.Boiler().Etcd.Endpoints
+  EtcdEndpointsKey Key = "BOILER_ETCD_ENDPOINTS"
+  // Deprecated: use env.Boiler().Etcd.Endpoints
+  EtcdEndpointsDefault Env = "localhost:2379"
+)
+
+// Settings of the boiler read from env vars by Load
+type Settings struct {
+  // App env: PRODUCTION, STAGING or LOCAL, unknown envs are treated as LOCAL
+  AppEnv Env `env:"BOILER_APP_ENV" default:"LOCAL"`
+
+  PrometheusEndpoint string `env:"BOILER_PROMETHEUS_ENDPOINT" default:"localhost:9090"`
+  GrafanaEndpoint    string `env:"BOILER_GRAFANA_ENDPOINT" default:"localhost:3000"`
+  JaegerEndpoint     string `env:"BOILER_JAEGER_ENDPOINT" default:"localhost:4318"`
+
+  Ports  PortSettings
+  Etcd   EtcdSettings
+  Config ConfigSettings
+}
+
+// PortSettings serve ports of the app servers
+type PortSettings struct {
+  Grpc          int `env:"BOILER_GRPC_PORT" default:"8082"`
+  GrpcHttpProxy int `env:"BOILER_GRPC_HTTP_PROXY_PORT" default:"8084"`
+  Gqlgen        int `env:"BOILER_GQLGEN_PORT" default:"8080"`
+  DutyHttp      int `env:"BOILER_DUTY_HTTP_PORT" default:"8092"`
+}
+
+// EtcdSettings connection and key layout of the etcd values provider
+type EtcdSettings struct {
+  // Comma separated endpoints
+  Endpoints []string `env:"BOILER_ETCD_ENDPOINTS" default:"localhost:2379"`
+
+  Username string `env:"BOILER_ETCD_USERNAME" default:"boiler"`
+  Password string `env:"BOILER_ETCD_PASSWORD"`
+
+  // TLS is enabled when CA file is set
+  TLSCAFile   string `env:"BOILER_ETCD_TLS_CA_FILE"`
+  TLSCertFile string `env:"BOILER_ETCD_TLS_CERT_FILE"`
+  TLSKeyFile  string `env:"BOILER_ETCD_TLS_KEY_FILE"`
+
+  // Key layout: flat or hierarchical
+  Layout     string `env:"BOILER_ETCD_LAYOUT" default:"flat"`
+  LayoutRoot string `env:"BOILER_ETCD_LAYOUT_ROOT" default:"boiler"`
+
+  // Comma separated shared namespaces
+  Namespaces []string `env:"BOILER_ETCD_NAMESPACES"`
+}
+
+// ConfigSettings local config files and secrets
+type ConfigSettings struct {
+  // Directory with per-key config files like mounted config map
+  KeysDir string `env:"BOILER_CONFIG_KEYS_DIR"`
+
+  // Base64 encoded key of the config secrets or path to the key file
+  SecretKey     string `env:"BOILER_CONFIG_SECRET_KEY"`
+  SecretKeyPath string `env:"BOILER_CONFIG_SECRET_KEY_PATH" default:".config/secret.key"`
+}
+
+// LoadSettings reads boiler settings from env,
+// settings are returned with defaults of the invalid values on error
+func LoadSettings() (*Settings, error) {
+  settings := new(Settings)
+  err := Load(settings)
+
+  if _, ok := knownAppEnvs[settings.AppEnv]; !ok {
+    settings.AppEnv = LocalEnv
+  }
+  return settings, err
+}
+
+var (
+  boilerMu       sync.Mutex
+  boilerSettings atomic.Pointer[Settings]
+)
+
+// Boiler returns boiler settings read from env once, settings must not be modified,
+// invalid values are replaced by defaults and logged
+func Boiler() *Settings {
+  if settings := boilerSettings.Load(); settings != nil {
+    return settings
+  }
+  boilerMu.Lock()
+  defer boilerMu.Unlock()
+
+  if settings := boilerSettings.Load(); settings != nil {
+    return settings
+  }
+  settings, err := LoadSettings()
+  if err != nil {
+    log.Errorf("env: invalid boiler settings, defaults used: %v", err)
+  }
+  boilerSettings.Store(settings)
+
+  return settings
+}
+
+// Reset drops settings read by Boiler, next call reads env again,
+// used by tests changing env
+func Reset() {
+  boilerMu.Lock()
+  defer boilerMu.Unlock()
+
+  boilerSettings.Store(nil)
+}
@@ -0,0 +1,17 @@
+package flags
+
+import "context"
+
+type ctxKey struct{}
+
+// ContextKey returns key for rollouts hashing, like user or tenant id
+func ContextKey(ctx context.Context) string {
+  if key, ok := ctx.Value(ctxKey{}).(string); ok {
+    return key
+  }
+  return ""
+}
+
+func ContextWithKey(parent context.Context, key string) context.Context {
+  return context.WithValue(parent, ctxKey{}, key)
+}
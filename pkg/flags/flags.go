@@ -0,0 +1,139 @@
+package flags
+
+import (
+  "context"
+  "hash/fnv"
+  "sync"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/env"
+)
+
+const (
+  ReasonNotFound = "not_found"
+  ReasonInvalid  = "invalid"
+  ReasonDisabled = "disabled"
+  ReasonRollout  = "rollout"
+  ReasonEnabled  = "enabled"
+)
+
+// Evaluation result of the flag evaluation
+type Evaluation struct {
+  Enabled bool
+  Variant string
+  Reason  string
+}
+
+type cachedSpec struct {
+  raw  string
+  spec *Spec
+  err  error
+}
+
+var (
+  // Parsed specs by flag name
+  specs sync.Map
+)
+
+func Enabled(ctx context.Context, name string) bool {
+  return Evaluate(ctx, name).Enabled
+}
+
+func Variant(ctx context.Context, name string) string {
+  return Evaluate(ctx, name).Variant
+}
+
+// Evaluate evaluates the flag stored in config value with the name
+func Evaluate(ctx context.Context, name string) Evaluation {
+  initMetrics()
+
+  eval := evaluate(ctx, name)
+  m.incEvaluations(name, eval)
+
+  return eval
+}
+
+func evaluate(ctx context.Context, name string) Evaluation {
+  value := config.ContextClient(ctx).GetValue(ctx, name)
+
+  if value == nil || value.IsNil() {
+    return Evaluation{Reason: ReasonNotFound}
+  }
+  spec, err := loadSpec(name, value.String())
+  if err != nil {
+    return Evaluation{Reason: ReasonInvalid}
+  }
+  return evaluateSpec(spec.forEnv(env.AppEnv().String()), name, ContextKey(ctx))
+}
+
+func evaluateSpec(spec *Spec, name, key string) Evaluation {
+  if !spec.enabled() {
+    return Evaluation{
+      Variant: spec.DefaultVariant,
+      Reason:  ReasonDisabled,
+    }
+  }
+  if r := spec.Rollout; r != nil && *r < 100 {
+    // Keys out of rollout percent and unknown keys are disabled
+    if key == "" || bucket(name, key, 10000) >= uint64(*r*100) {
+      return Evaluation{
+        Variant: spec.DefaultVariant,
+        Reason:  ReasonRollout,
+      }
+    }
+  }
+  return Evaluation{
+    Enabled: true,
+    Variant: pickVariant(spec, name, key),
+    Reason:  ReasonEnabled,
+  }
+}
+
+func pickVariant(spec *Spec, name, key string) string {
+  total := spec.totalWeight()
+  if total == 0 {
+    return spec.DefaultVariant
+  }
+  point := bucket(name+":variant", key, total)
+
+  for _, variant := range spec.Variants {
+    if point < uint64(variant.Weight) {
+      return variant.Name
+    }
+    point -= uint64(variant.Weight)
+  }
+  return spec.DefaultVariant
+}
+
+// bucket hashes the key salted by the flag name into [0, n)
+func bucket(salt, key string, n uint64) uint64 {
+  h := fnv.New64a()
+
+  _, _ = h.Write([]byte(salt))
+  _, _ = h.Write([]byte{':'})
+  _, _ = h.Write([]byte(key))
+
+  return h.Sum64() % n
+}
+
+func loadSpec(name, raw string) (*Spec, error) {
+  if cached, ok := specs.Load(name); ok {
+    if c := cached.(*cachedSpec); c.raw == raw {
+      return c.spec, c.err
+    }
+  }
+  spec, err := ParseSpec(raw)
+
+  if err != nil {
+    log.Errorf("flags: flag %s invalid: %v", name, err)
+  } else {
+    log.Infof("flags: flag %s loaded: %s", name, raw)
+  }
+  specs.Store(name, &cachedSpec{
+    raw:  raw,
+    spec: spec,
+    err:  err,
+  })
+  return spec, err
+}
@@ -0,0 +1,83 @@
+package flags
+
+import (
+  "fmt"
+  "testing"
+
+  "github.com/go-playground/assert/v2"
+)
+
+func Test_EvaluateSpecRollout(t *testing.T) {
+  spec, err := ParseSpec(`{"enabled": true, "rollout": 30}`)
+  assert.Equal(t, nil, err)
+
+  var enabled int
+
+  for i := 0; i < 10000; i++ {
+    if evaluateSpec(spec, "test_flag", fmt.Sprint(i)).Enabled {
+      enabled++
+    }
+  }
+  assert.Equal(t, true, enabled > 2700 && enabled < 3300)
+
+  // Same key evaluated stable
+  first := evaluateSpec(spec, "test_flag", "user_1")
+  second := evaluateSpec(spec, "test_flag", "user_1")
+  assert.Equal(t, first, second)
+
+  // Unknown key out of rollout
+  assert.Equal(t, ReasonRollout, evaluateSpec(spec, "test_flag", "").Reason)
+}
+
+func Test_EvaluateSpecVariantsAndEnvs(t *testing.T) {
+  spec, err := ParseSpec(`
+enabled: true
+default_variant: control
+variants:
+  - name: red
+    weight: 1
+  - name: blue
+    weight: 3
+envs:
+  PRODUCTION:
+    enabled: false
+    default_variant: control
+`)
+  assert.Equal(t, nil, err)
+
+  variants := map[string]int{}
+
+  for i := 0; i < 4000; i++ {
+    variants[evaluateSpec(spec, "test_flag", fmt.Sprint(i)).Variant]++
+  }
+  assert.Equal(t, true, variants["blue"] > 2*variants["red"])
+
+  eval := evaluateSpec(spec.forEnv("production"), "test_flag", "user_1")
+  assert.Equal(t, Evaluation{Variant: "control", Reason: ReasonDisabled}, eval)
+}
+
+func Test_EvaluateSpecEnvMerged(t *testing.T) {
+  spec, err := ParseSpec(`
+enabled: false
+default_variant: control
+variants:
+  - name: red
+    weight: 1
+envs:
+  PRODUCTION:
+    enabled: true
+    rollout: 100
+`)
+  assert.Equal(t, nil, err)
+
+  eval := evaluateSpec(spec.forEnv("production"), "test_flag", "user_1")
+  assert.Equal(t, Evaluation{Enabled: true, Variant: "red", Reason: ReasonEnabled}, eval)
+
+  eval = evaluateSpec(spec.forEnv("staging"), "test_flag", "user_1")
+  assert.Equal(t, Evaluation{Variant: "control", Reason: ReasonDisabled}, eval)
+}
+
+func Test_ParseSpecInvalid(t *testing.T) {
+  _, err := ParseSpec(`{"enabled": true, "rollout": 120, "variants": [{"name": ""}]}`)
+  assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,48 @@
+package flags
+
+import (
+  "strconv"
+  "sync"
+
+  "github.com/prometheus/client_golang/prometheus"
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/metrics"
+)
+
+type flagsMetrics struct {
+  evaluations *prometheus.CounterVec
+}
+
+var (
+  m    *flagsMetrics
+  once sync.Once
+)
+
+func initMetrics() {
+  once.Do(func() {
+    // Evaluations of the flags
+    evaluationsCounter := metrics.NewCounterVec(
+      "flag_evaluation_counter",
+      "Counter of feature flag evaluations",
+      []string{"flag", "enabled", "variant", "reason"},
+    )
+
+    m = &flagsMetrics{
+      evaluations: evaluationsCounter,
+    }
+  })
+}
+
+func (m *flagsMetrics) incEvaluations(name string, eval Evaluation) {
+  labels := []string{
+    name,
+    strconv.FormatBool(eval.Enabled),
+    eval.Variant,
+    eval.Reason,
+  }
+  if counter, err := m.evaluations.GetMetricWithLabelValues(labels...); err != nil {
+    log.Errorf("flags: evaluation counter error: %v", err)
+  } else {
+    counter.Inc()
+  }
+}
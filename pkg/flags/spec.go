@@ -0,0 +1,127 @@
+package flags
+
+import (
+  "errors"
+  "fmt"
+  "strings"
+
+  "github.com/ushakovn/boiler/internal/pkg/builder"
+  "gopkg.in/yaml.v3"
+)
+
+// Spec flag specification stored as config value in JSON or YAML:
+//
+//  {"enabled": true, "rollout": 25, "variants": [{"name": "a", "weight": 1}], "envs": {"PRODUCTION": {"enabled": false}}}
+//
+// Env spec overrides only the specified fields of the base spec
+type Spec struct {
+  // Flag turned on, turned off if not specified
+  Enabled *bool `yaml:"enabled" json:"enabled"`
+  // Percent of context keys for which flag is enabled, all keys if not specified
+  Rollout *float64 `yaml:"rollout" json:"rollout"`
+  // Weighted variants of enabled flag
+  Variants []*VariantSpec `yaml:"variants" json:"variants"`
+  // Variant of disabled flag
+  DefaultVariant string `yaml:"default_variant" json:"default_variant"`
+  // Specs override for app envs
+  Envs map[string]*Spec `yaml:"envs" json:"envs"`
+}
+
+type VariantSpec struct {
+  Name   string `yaml:"name" json:"name"`
+  Weight uint32 `yaml:"weight" json:"weight"`
+}
+
+func ParseSpec(raw string) (*Spec, error) {
+  spec := &Spec{}
+
+  if err := yaml.Unmarshal([]byte(raw), spec); err != nil {
+    return nil, fmt.Errorf("yaml unmarshal failed: %w", err)
+  }
+  if err := spec.Validate(); err != nil {
+    return nil, err
+  }
+  return spec, nil
+}
+
+func (s *Spec) Validate() error {
+  b := builder.NewBuilder()
+  s.validate(&b, "")
+
+  if str := b.String(); str != "" {
+    return errors.New(str)
+  }
+  return nil
+}
+
+func (s *Spec) validate(b *builder.Builder, prefix string) {
+  if r := s.Rollout; r != nil && (*r < 0 || *r > 100) {
+    b.Write("\t%srollout must be in range [0, 100]: %v\n", prefix, *r)
+  }
+  names := map[string]struct{}{}
+
+  for _, variant := range s.Variants {
+    if variant.Name == "" {
+      b.Write("\t%svariant name not specified\n", prefix)
+    }
+    if _, ok := names[variant.Name]; ok {
+      b.Write("\t%svariant duplicated: %s\n", prefix, variant.Name)
+    }
+    names[variant.Name] = struct{}{}
+  }
+  if len(s.Variants) > 0 && s.totalWeight() == 0 {
+    b.Write("\t%svariants weights not specified\n", prefix)
+  }
+  for envName, envSpec := range s.Envs {
+    if envSpec == nil {
+      continue
+    }
+    if len(envSpec.Envs) > 0 {
+      b.Write("\t%senv %s spec contains nested envs\n", prefix, envName)
+    }
+    envSpec.validate(b, fmt.Sprintf("%senv %s: ", prefix, envName))
+  }
+}
+
+// forEnv returns spec merged with override for app env if specified
+func (s *Spec) forEnv(appEnv string) *Spec {
+  for envName, envSpec := range s.Envs {
+    if envSpec != nil && strings.EqualFold(envName, appEnv) {
+      return s.merge(envSpec)
+    }
+  }
+  return s
+}
+
+// merge returns copy of the spec with the fields specified in override
+func (s *Spec) merge(override *Spec) *Spec {
+  merged := *s
+  merged.Envs = nil
+
+  if override.Enabled != nil {
+    merged.Enabled = override.Enabled
+  }
+  if override.Rollout != nil {
+    merged.Rollout = override.Rollout
+  }
+  if override.Variants != nil {
+    merged.Variants = override.Variants
+  }
+  if override.DefaultVariant != "" {
+    merged.DefaultVariant = override.DefaultVariant
+  }
+  return &merged
+}
+
+func (s *Spec) enabled() bool {
+  return s.Enabled != nil && *s.Enabled
+}
+
+func (s *Spec) totalWeight() uint64 {
+  var total uint64
+
+  for _, variant := range s.Variants {
+    total += uint64(variant.Weight)
+  }
+  return total
+}
@@ -0,0 +1,40 @@
+package producer
+
+import (
+  "context"
+
+  "github.com/IBM/sarama"
+  "github.com/ushakovn/boiler/pkg/breaker"
+)
+
+type breakerProducer struct {
+  sarama.SyncProducer
+  breaker *breaker.Breaker
+}
+
+// WithBreaker guards producer sends with the breaker
+func WithBreaker(producer sarama.SyncProducer, b *breaker.Breaker) sarama.SyncProducer {
+  return &breakerProducer{
+    SyncProducer: producer,
+    breaker:      b,
+  }
+}
+
+func (p *breakerProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+  var (
+    partition int32
+    offset    int64
+  )
+  err := p.breaker.Do(context.Background(), func(context.Context) error {
+    var err error
+    partition, offset, err = p.SyncProducer.SendMessage(msg)
+    return err
+  })
+  return partition, offset, err
+}
+
+func (p *breakerProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+  return p.breaker.Do(context.Background(), func(context.Context) error {
+    return p.SyncProducer.SendMessages(msgs)
+  })
+}
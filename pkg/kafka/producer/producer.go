@@ -4,10 +4,13 @@ import (
   "fmt"
 
   "github.com/IBM/sarama"
+  "github.com/ushakovn/boiler/pkg/breaker"
 )
 
 type Config struct {
   Brokers []string
+  // Optional breaker for sends
+  Breaker *breaker.Breaker
 }
 
 func New(config Config) (sarama.SyncProducer, error) {
@@ -15,6 +18,9 @@ func New(config Config) (sarama.SyncProducer, error) {
   if err != nil {
     return nil, fmt.Errorf("sarama.NewSyncProducer: %w", err)
   }
+  if config.Breaker != nil {
+    producer = WithBreaker(producer, config.Breaker)
+  }
   return producer, nil
 }
 
@@ -0,0 +1,196 @@
+package logger
+
+import (
+  "fmt"
+  "strings"
+  "sync"
+  "time"
+
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+// Log formats
+const (
+  FormatJSON   = "json"
+  FormatLogfmt = "logfmt"
+  FormatText   = "text"
+)
+
+const timestampFormat = "2006-01-02 15:04:05"
+
+var formatters = map[string]func() log.Formatter{
+  FormatJSON: func() log.Formatter {
+    return &log.JSONFormatter{
+      TimestampFormat: timestampFormat,
+    }
+  },
+  FormatLogfmt: func() log.Formatter {
+    return &log.TextFormatter{
+      DisableColors:   true,
+      FullTimestamp:   true,
+      TimestampFormat: timestampFormat,
+    }
+  },
+  FormatText: func() log.Formatter {
+    return &log.TextFormatter{
+      FullTimestamp:   true,
+      TimestampFormat: timestampFormat,
+    }
+  },
+}
+
+// filterFormatter drops entries disabled by package levels and sampling,
+// logrus writes nothing for empty formatted entry
+type filterFormatter struct {
+  formatter     log.Formatter
+  level         log.Level
+  packageLevels map[string]log.Level
+  sampler       *sampler
+}
+
+func (f *filterFormatter) Format(entry *log.Entry) ([]byte, error) {
+  if entry.Level > f.entryLevel(entry) {
+    return nil, nil
+  }
+  if f.sampler != nil && !f.sampler.allow(entry) {
+    return nil, nil
+  }
+  // Caller reported for filtering only
+  entry.Caller = nil
+
+  return f.formatter.Format(entry)
+}
+
+// entryLevel returns level of the longest package matching entry caller
+func (f *filterFormatter) entryLevel(entry *log.Entry) log.Level {
+  if len(f.packageLevels) == 0 || entry.Caller == nil {
+    return f.level
+  }
+  pkg := callerPackage(entry.Caller.Function)
+
+  level := f.level
+  matched := ""
+
+  for prefix, packageLevel := range f.packageLevels {
+    if pkg != prefix && !strings.HasPrefix(pkg, prefix+"/") {
+      continue
+    }
+    if len(prefix) > len(matched) {
+      level = packageLevel
+      matched = prefix
+    }
+  }
+  return level
+}
+
+// callerPackage returns package path of the function like
+// github.com/org/repo/pkg.(*Type).Method
+func callerPackage(function string) string {
+  slash := strings.LastIndex(function, "/")
+
+  if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+    return function[:slash+1+dot]
+  }
+  return function
+}
+
+func parsePackageLevels(raw map[string]string) (map[string]log.Level, error) {
+  levels := make(map[string]log.Level, len(raw))
+
+  for pkg, rawLevel := range raw {
+    level, err := log.ParseLevel(rawLevel)
+    if err != nil {
+      return nil, fmt.Errorf("package %s: log.ParseLevel: %w", pkg, err)
+    }
+    levels[strings.TrimSuffix(pkg, "/")] = level
+  }
+  return levels, nil
+}
+
+// sampling of the repetitive entries: first entries with the same
+// level and message are logged each tick, then every thereafter entry
+type sampling struct {
+  tick       time.Duration
+  first      int
+  thereafter int
+}
+
+func parseSampling(value types.Value) (*sampling, error) {
+  var raw struct {
+    Tick       string `json:"tick"`
+    First      int    `json:"first"`
+    Thereafter int    `json:"thereafter"`
+  }
+  if err := value.JSON(&raw); err != nil {
+    return nil, fmt.Errorf("value.JSON: %w", err)
+  }
+  s := &sampling{
+    tick:       time.Second,
+    first:      raw.First,
+    thereafter: raw.Thereafter,
+  }
+  if raw.Tick != "" {
+    tick, err := time.ParseDuration(raw.Tick)
+    if err != nil {
+      return nil, fmt.Errorf("time.ParseDuration: %w", err)
+    }
+    s.tick = tick
+  }
+  if s.tick <= 0 || s.first < 0 || s.thereafter < 0 {
+    return nil, fmt.Errorf("sampling tick must be positive, first and thereafter not negative")
+  }
+  return s, nil
+}
+
+type sampleKey struct {
+  level   log.Level
+  message string
+}
+
+type sampler struct {
+  sampling
+
+  mu      sync.Mutex
+  resetAt time.Time
+  counts  map[sampleKey]int
+}
+
+func newSampler(s *sampling) *sampler {
+  if s == nil {
+    return nil
+  }
+  return &sampler{
+    sampling: *s,
+    counts:   map[sampleKey]int{},
+  }
+}
+
+func (s *sampler) allow(entry *log.Entry) bool {
+  // Panic and fatal entries are not sampled
+  if entry.Level <= log.FatalLevel {
+    return true
+  }
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  now := entry.Time
+  if now.IsZero() {
+    now = time.Now()
+  }
+  if !now.Before(s.resetAt) {
+    s.counts = map[sampleKey]int{}
+    s.resetAt = now.Add(s.tick)
+  }
+  key := sampleKey{
+    level:   entry.Level,
+    message: entry.Message,
+  }
+  s.counts[key]++
+  count := s.counts[key]
+
+  if count <= s.first {
+    return true
+  }
+  return s.thereafter > 0 && (count-s.first)%s.thereafter == 0
+}
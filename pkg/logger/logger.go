@@ -1,24 +1,172 @@
 package logger
 
 import (
+  "context"
+  "fmt"
+  "sync"
+
   log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config"
+  "github.com/ushakovn/boiler/pkg/config/types"
   "github.com/ushakovn/boiler/pkg/env"
 )
 
+// Config keys of the log options
+const (
+  LevelKey         = "log_level"
+  FormatKey        = "log_format"
+  SamplingKey      = "log_sampling"
+  PackageLevelsKey = "log_package_levels"
+)
+
+var configKeys = []string{
+  LevelKey,
+  FormatKey,
+  SamplingKey,
+  PackageLevelsKey,
+}
+
+var (
+  mu      sync.Mutex
+  current = defaultOptions()
+)
+
+// SetDefaultLogOptions sets log options by app env:
+// text format for local run and compact JSON otherwise
 func SetDefaultLogOptions() {
-  appEnv := env.AppEnv()
+  mu.Lock()
+  defer mu.Unlock()
+
+  current = defaultOptions()
+  current.apply(log.StandardLogger())
+}
+
+// WatchConfigOptions sets log options from the config keys and applies
+// changes of the keys until ctx done, options of the keys not set in config
+// are defaults by app env, invalid values are ignored
+func WatchConfigOptions(ctx context.Context) {
+  client := config.ContextClient(ctx)
 
-  if appEnv == env.LocalEnv {
-    // Set text formatter for local run
-    log.SetFormatter(&log.TextFormatter{
-      FullTimestamp:   true,
-      TimestampFormat: "2006-01-02 15:04:05",
+  for _, key := range configKeys {
+    key := key
+
+    // Current value delivered on subscribing
+    client.WatchValue(ctx, key, func(value types.Value) {
+      update(key, value)
     })
+  }
+}
+
+func update(key string, value types.Value) {
+  mu.Lock()
+  defer mu.Unlock()
+
+  next, err := current.withValue(key, value)
+  if err != nil {
+    log.Errorf("logger: invalid %s value, previous kept: %v", key, err)
     return
   }
-  // Set JSON formatter otherwise
-  log.SetFormatter(&log.JSONFormatter{
-    PrettyPrint:     true,
-    TimestampFormat: "2006-01-02 15:04:05",
+  current = next
+  current.apply(log.StandardLogger())
+}
+
+type options struct {
+  level         log.Level
+  format        string
+  sampling      *sampling
+  packageLevels map[string]log.Level
+}
+
+func defaultOptions() *options {
+  format := FormatJSON
+
+  if env.AppEnv() == env.LocalEnv {
+    format = FormatText
+  }
+  return &options{
+    level:  log.InfoLevel,
+    format: format,
+  }
+}
+
+// withValue returns copy of the options with value of the key,
+// nil value resets option to default
+func (o *options) withValue(key string, value types.Value) (*options, error) {
+  next := *o
+  defaults := defaultOptions()
+
+  if value == nil || value.IsNil() {
+    switch key {
+    case LevelKey:
+      next.level = defaults.level
+    case FormatKey:
+      next.format = defaults.format
+    case SamplingKey:
+      next.sampling = nil
+    case PackageLevelsKey:
+      next.packageLevels = nil
+    }
+    return &next, nil
+  }
+  switch key {
+  case LevelKey:
+    level, err := log.ParseLevel(value.String())
+    if err != nil {
+      return nil, fmt.Errorf("log.ParseLevel: %w", err)
+    }
+    next.level = level
+
+  case FormatKey:
+    format := value.String()
+
+    if _, ok := formatters[format]; !ok {
+      return nil, fmt.Errorf("unknown log format: %s", format)
+    }
+    next.format = format
+
+  case SamplingKey:
+    s, err := parseSampling(value)
+    if err != nil {
+      return nil, err
+    }
+    next.sampling = s
+
+  case PackageLevelsKey:
+    levels, err := parsePackageLevels(value.StringMap())
+    if err != nil {
+      return nil, err
+    }
+    next.packageLevels = levels
+  }
+  return &next, nil
+}
+
+func (o *options) apply(logger *log.Logger) {
+  formatter := formatters[o.format]()
+
+  if o.sampling == nil && len(o.packageLevels) == 0 {
+    logger.SetReportCaller(false)
+    logger.SetFormatter(formatter)
+    logger.SetLevel(o.level)
+    return
+  }
+  // Entries filtered by formatter, so logger level
+  // is the most verbose of the global and package levels
+  level := o.level
+
+  for _, packageLevel := range o.packageLevels {
+    if packageLevel > level {
+      level = packageLevel
+    }
+  }
+  // Caller used to find package of the entry
+  logger.SetReportCaller(len(o.packageLevels) > 0)
+
+  logger.SetFormatter(&filterFormatter{
+    formatter:     formatter,
+    level:         o.level,
+    packageLevels: o.packageLevels,
+    sampler:       newSampler(o.sampling),
   })
+  logger.SetLevel(level)
 }
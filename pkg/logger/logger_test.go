@@ -0,0 +1,134 @@
+package logger
+
+import (
+  "bytes"
+  "runtime"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+  log "github.com/sirupsen/logrus"
+  "github.com/ushakovn/boiler/pkg/config/types"
+)
+
+func Test_OptionsWithValue(t *testing.T) {
+  o := &options{
+    level:  log.InfoLevel,
+    format: FormatJSON,
+  }
+  next, err := o.withValue(LevelKey, types.NewValue("debug"))
+  assert.Equal(t, nil, err)
+  assert.Equal(t, log.DebugLevel, next.level)
+
+  // Options copied
+  assert.Equal(t, log.InfoLevel, o.level)
+
+  _, err = o.withValue(LevelKey, types.NewValue("verbose"))
+  assert.NotEqual(t, nil, err)
+
+  _, err = o.withValue(FormatKey, types.NewValue("xml"))
+  assert.NotEqual(t, nil, err)
+
+  next, err = o.withValue(FormatKey, types.NewValue(FormatLogfmt))
+  assert.Equal(t, nil, err)
+  assert.Equal(t, FormatLogfmt, next.format)
+
+  next, err = o.withValue(SamplingKey, types.NewValue(`{"tick": "10s", "first": 2, "thereafter": 3}`))
+  assert.Equal(t, nil, err)
+  assert.Equal(t, &sampling{tick: 10 * time.Second, first: 2, thereafter: 3}, next.sampling)
+
+  next, err = next.withValue(PackageLevelsKey, types.NewValue(map[string]string{"github.com/org/repo/": "trace"}))
+  assert.Equal(t, nil, err)
+  assert.Equal(t, map[string]log.Level{"github.com/org/repo": log.TraceLevel}, next.packageLevels)
+
+  // Deleted values reset to defaults
+  next, err = next.withValue(SamplingKey, types.NewNilValue())
+  assert.Equal(t, nil, err)
+  assert.Equal(t, (*sampling)(nil), next.sampling)
+}
+
+func Test_FilterPackageLevels(t *testing.T) {
+  f := &filterFormatter{
+    formatter: &log.JSONFormatter{},
+    level:     log.InfoLevel,
+    packageLevels: map[string]log.Level{
+      "github.com/org/repo":         log.ErrorLevel,
+      "github.com/org/repo/pkg/api": log.DebugLevel,
+    },
+  }
+  entry := func(level log.Level, function string) *log.Entry {
+    return &log.Entry{
+      Logger:  log.New(),
+      Level:   level,
+      Message: "message",
+      Caller:  &runtime.Frame{Function: function},
+    }
+  }
+  formatted := func(e *log.Entry) bool {
+    buf, err := f.Format(e)
+    assert.Equal(t, nil, err)
+    return len(buf) > 0
+  }
+  assert.Equal(t, true, formatted(entry(log.DebugLevel, "github.com/org/repo/pkg/api.(*Server).Get")))
+  assert.Equal(t, false, formatted(entry(log.InfoLevel, "github.com/org/repo/pkg/db.Query")))
+  assert.Equal(t, true, formatted(entry(log.ErrorLevel, "github.com/org/repo.main")))
+  assert.Equal(t, true, formatted(entry(log.InfoLevel, "github.com/org/repository.main")))
+  assert.Equal(t, false, formatted(entry(log.DebugLevel, "main.main")))
+}
+
+func Test_FilterSampling(t *testing.T) {
+  f := &filterFormatter{
+    formatter: &log.JSONFormatter{},
+    level:     log.InfoLevel,
+    sampler:   newSampler(&sampling{tick: time.Second, first: 2, thereafter: 3}),
+  }
+  now := time.Now()
+
+  logged := 0
+
+  for i := 0; i < 10; i++ {
+    buf, _ := f.Format(&log.Entry{
+      Logger:  log.New(),
+      Level:   log.InfoLevel,
+      Message: "repeated",
+      Time:    now,
+    })
+    if len(buf) > 0 {
+      logged++
+    }
+  }
+  // First 2 then 5th and 8th
+  assert.Equal(t, 4, logged)
+
+  // Counts reset next tick
+  buf, _ := f.Format(&log.Entry{
+    Logger:  log.New(),
+    Level:   log.InfoLevel,
+    Message: "repeated",
+    Time:    now.Add(time.Second),
+  })
+  assert.Equal(t, true, len(buf) > 0)
+}
+
+func Test_ApplyOptions(t *testing.T) {
+  var out bytes.Buffer
+
+  logger := log.New()
+  logger.SetOutput(&out)
+
+  o := &options{
+    level:         log.WarnLevel,
+    format:        FormatLogfmt,
+    packageLevels: map[string]log.Level{"github.com/ushakovn/boiler/pkg/logger": log.DebugLevel},
+  }
+  o.apply(logger)
+
+  assert.Equal(t, log.DebugLevel, logger.GetLevel())
+
+  logger.Debugf("package debug")
+  assert.Equal(t, true, strings.Contains(out.String(), `msg="package debug"`))
+
+  // Caller not written
+  assert.Equal(t, false, strings.Contains(out.String(), "func="))
+}
@@ -0,0 +1,83 @@
+package retries
+
+import (
+  "math"
+  "math/rand"
+  "sync"
+  "time"
+)
+
+// Backoff returns wait before the next attempt
+// by the failed attempt number and the previous wait
+type Backoff func(attempt int, prev time.Duration) time.Duration
+
+var (
+  randMu sync.Mutex
+  randGn = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func randInt63n(n int64) int64 {
+  if n <= 0 {
+    return 0
+  }
+  randMu.Lock()
+  defer randMu.Unlock()
+
+  return randGn.Int63n(n)
+}
+
+func ConstantBackoff(wait time.Duration) Backoff {
+  return func(_ int, _ time.Duration) time.Duration {
+    return wait
+  }
+}
+
+// ExponentialBackoff waits base * multiplier^(attempt-1)
+func ExponentialBackoff(base time.Duration, multiplier float64) Backoff {
+  return func(attempt int, _ time.Duration) time.Duration {
+    wait := float64(base) * math.Pow(multiplier, float64(attempt-1))
+
+    if wait >= math.MaxInt64 {
+      return time.Duration(math.MaxInt64)
+    }
+    return time.Duration(wait)
+  }
+}
+
+// DecorrelatedJitterBackoff waits random between base and 3 * previous wait, capped by cap
+func DecorrelatedJitterBackoff(base, cap time.Duration) Backoff {
+  return func(_ int, prev time.Duration) time.Duration {
+    if prev < base {
+      prev = base
+    }
+    upper := 3 * prev
+
+    if upper > cap || upper < 0 {
+      upper = cap
+    }
+    if upper <= base {
+      return upper
+    }
+    return base + time.Duration(randInt63n(int64(upper-base)))
+  }
+}
+
+// CappedBackoff limits waits of backoff by cap
+func CappedBackoff(backoff Backoff, cap time.Duration) Backoff {
+  return func(attempt int, prev time.Duration) time.Duration {
+    if wait := backoff(attempt, prev); wait < cap {
+      return wait
+    }
+    return cap
+  }
+}
+
+// JitterBackoff spreads waits of backoff randomly by the fraction of wait
+func JitterBackoff(backoff Backoff, fraction float64) Backoff {
+  return func(attempt int, prev time.Duration) time.Duration {
+    wait := backoff(attempt, prev)
+    delta := time.Duration(float64(wait) * fraction)
+
+    return wait - delta + time.Duration(randInt63n(int64(2*delta)+1))
+  }
+}
@@ -0,0 +1,50 @@
+package retries
+
+import (
+  "errors"
+  "sync"
+)
+
+var ErrBudgetExhausted = errors.New("retries: budget exhausted")
+
+// Budget limits retries across calls like gRPC retry throttling:
+// each failure withdraws one token, each success deposits ratio tokens,
+// retries allowed while tokens exceed half of max tokens
+type Budget struct {
+  mu        sync.Mutex
+  tokens    float64
+  maxTokens float64
+  ratio     float64
+}
+
+func NewBudget(maxTokens, ratio float64) *Budget {
+  return &Budget{
+    tokens:    maxTokens,
+    maxTokens: maxTokens,
+    ratio:     ratio,
+  }
+}
+
+func (b *Budget) onResult(success bool) {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  if success {
+    b.tokens += b.ratio
+  } else {
+    b.tokens--
+  }
+  if b.tokens > b.maxTokens {
+    b.tokens = b.maxTokens
+  }
+  if b.tokens < 0 {
+    b.tokens = 0
+  }
+}
+
+func (b *Budget) allow() bool {
+  b.mu.Lock()
+  defer b.mu.Unlock()
+
+  return b.tokens > b.maxTokens/2
+}
@@ -2,23 +2,69 @@ package retries
 
 import (
   "context"
+  "errors"
   "fmt"
+  "strings"
   "time"
 
   log "github.com/sirupsen/logrus"
-  "github.com/ushakovn/boiler/pkg/timer"
+  "github.com/ushakovn/boiler/pkg/breaker"
 )
 
 type Options struct {
   Count int
-  Wait  time.Duration
+  // Fixed wait used if backoff not specified
+  Wait time.Duration
+  // Optional backoff strategy
+  Backoff Backoff
+  // Optional classifier, all errors retried if not specified
+  Retryable Retryable
+  // Optional budget shared between calls
+  Budget *Budget
+  // Optional hook called before each retry
+  OnRetry func(attempt int, err error, wait time.Duration)
+  // Optional breaker for calls
+  Breaker *breaker.Breaker
+}
+
+// Error contains errors of all attempts
+type Error struct {
+  Errors []error
+  // Cause of stopping retries before attempts exhausting like
+  // budget exhausting or context cancelling, nil otherwise
+  Cause error
+}
+
+func (e *Error) Error() string {
+  parts := make([]string, 0, len(e.Errors)+1)
+
+  for index, err := range e.Errors {
+    parts = append(parts, fmt.Sprintf("attempt %d: %v", index+1, err))
+  }
+  if e.Cause != nil {
+    parts = append(parts, e.Cause.Error())
+  }
+  return strings.Join(parts, "; ")
+}
+
+// Unwrap returns the last attempt error
+func (e *Error) Unwrap() error {
+  if len(e.Errors) == 0 {
+    return nil
+  }
+  return e.Errors[len(e.Errors)-1]
+}
+
+// Is reports if the cause matches target, attempt errors are matched with Unwrap
+func (e *Error) Is(target error) bool {
+  return e.Cause != nil && errors.Is(e.Cause, target)
 }
 
 func (o Options) Validate() error {
   if o.Count <= 0 {
     return fmt.Errorf("count must be positive")
   }
-  if o.Wait <= 0 {
+  if o.Wait <= 0 && o.Backoff == nil {
     return fmt.Errorf("wait must be positive")
   }
   return nil
@@ -35,34 +81,94 @@ func (o Options) WithDefault() Options {
   if o.Wait == 0 {
     o.Wait = wait
   }
+  if o.Backoff == nil {
+    o.Backoff = ConstantBackoff(o.Wait)
+  }
+  if o.Retryable == nil {
+    o.Retryable = AlwaysRetryable
+  }
   return o
 }
 
 func DoWithRetries(ctx context.Context, opt Options, f func(ctx context.Context) error) error {
-  opt = opt.WithDefault()
+  _, err := Do[struct{}](ctx, opt, func(ctx context.Context) (struct{}, error) {
+    return struct{}{}, f(ctx)
+  })
+  return err
+}
 
-  tm := timer.NewTimer(opt.Wait)
-  defer tm.Stop()
+// Do calls f until success, non retryable error or attempts exhausting
+func Do[T any](ctx context.Context, opt Options, f func(ctx context.Context) (T, error)) (T, error) {
+  opt = opt.WithDefault()
 
   var (
-    index int
-    err   error
+    errs []error
+    wait time.Duration
   )
+  for attempt := 1; ; attempt++ {
+    value, err := call(ctx, opt, f)
+
+    if opt.Budget != nil {
+      opt.Budget.onResult(err == nil)
+    }
+    if err == nil {
+      return value, nil
+    }
+    errs = append(errs, err)
+
+    switch {
+    case isBreakerRejected(err):
+      // Dependency must not be hammered by retries
+      return *new(T), &Error{Errors: errs}
+
+    case isPermanent(err) || !opt.Retryable(err):
+      return *new(T), &Error{Errors: errs}
+
+    case attempt >= opt.Count:
+      return *new(T), &Error{Errors: errs}
 
-  for index < opt.Count {
-    select {
-    case <-ctx.Done():
-      return fmt.Errorf("context cancelled")
-
-    case <-tm.Start():
-      if err = f(ctx); err != nil {
-        log.Errorf("DoWithRetries: %v", err)
-        index++
-        continue
-      }
-      return nil
+    case opt.Budget != nil && !opt.Budget.allow():
+      return *new(T), &Error{Errors: errs, Cause: ErrBudgetExhausted}
     }
+    wait = opt.Backoff(attempt, wait)
+
+    log.Debugf("retries: attempt %d failed, retry after %s: %v", attempt, wait, err)
+
+    if opt.OnRetry != nil {
+      opt.OnRetry(attempt, err, wait)
+    }
+    if err = sleep(ctx, wait); err != nil {
+      return *new(T), &Error{Errors: errs, Cause: err}
+    }
+  }
+}
+
+func call[T any](ctx context.Context, opt Options, f func(ctx context.Context) (T, error)) (T, error) {
+  if opt.Breaker == nil {
+    return f(ctx)
   }
+  var value T
 
-  return err
+  err := opt.Breaker.Do(ctx, func(ctx context.Context) error {
+    var err error
+    value, err = f(ctx)
+    return err
+  })
+  return value, err
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+  tm := time.NewTimer(wait)
+  defer tm.Stop()
+
+  select {
+  case <-ctx.Done():
+    return fmt.Errorf("context cancelled: %w", ctx.Err())
+  case <-tm.C:
+    return nil
+  }
+}
+
+func isBreakerRejected(err error) bool {
+  return errors.Is(err, breaker.ErrOpenState) || errors.Is(err, breaker.ErrTooManyRequests)
 }
@@ -0,0 +1,124 @@
+package retries
+
+import (
+  "context"
+  "errors"
+  "fmt"
+  "net"
+  "testing"
+  "time"
+
+  "github.com/go-playground/assert/v2"
+  "github.com/jackc/pgx/v5/pgconn"
+)
+
+var errTest = errors.New("test error")
+
+func Test_DoReturnsValue(t *testing.T) {
+  var calls int
+
+  value, err := Do[int](context.Background(), Options{Count: 3, Wait: time.Millisecond},
+    func(context.Context) (int, error) {
+      if calls++; calls < 3 {
+        return 0, errTest
+      }
+      return 42, nil
+    })
+
+  assert.Equal(t, nil, err)
+  assert.Equal(t, 42, value)
+  assert.Equal(t, 3, calls)
+}
+
+func Test_DoCollectsErrors(t *testing.T) {
+  var retries int
+
+  err := DoWithRetries(context.Background(),
+    Options{
+      Count:   3,
+      Backoff: ExponentialBackoff(time.Millisecond, 2),
+      OnRetry: func(int, error, time.Duration) { retries++ },
+    },
+    func(context.Context) error {
+      return errTest
+    })
+
+  var retriesErr *Error
+
+  assert.Equal(t, true, errors.As(err, &retriesErr))
+  assert.Equal(t, 3, len(retriesErr.Errors))
+  assert.Equal(t, true, errors.Is(err, errTest))
+  assert.Equal(t, 2, retries)
+}
+
+func Test_DoSkipsNotRetryable(t *testing.T) {
+  var calls int
+
+  err := DoWithRetries(context.Background(),
+    Options{Count: 5, Wait: time.Millisecond},
+    func(context.Context) error {
+      calls++
+      return Permanent(errTest)
+    })
+
+  assert.Equal(t, true, errors.Is(err, errTest))
+  assert.Equal(t, 1, calls)
+}
+
+func Test_DoLimitedByBudget(t *testing.T) {
+  var calls int
+
+  err := DoWithRetries(context.Background(),
+    Options{Count: 10, Wait: time.Millisecond, Budget: NewBudget(4, 0.1)},
+    func(context.Context) error {
+      calls++
+      return errTest
+    })
+
+  assert.Equal(t, true, errors.Is(err, ErrBudgetExhausted))
+  assert.Equal(t, true, errors.Is(err, errTest))
+  assert.Equal(t, 2, calls)
+}
+
+func Test_PgxRetryable(t *testing.T) {
+  assert.Equal(t, true, PgxRetryable(&pgconn.PgError{Code: "57P03"}))
+  assert.Equal(t, true, PgxRetryable(&pgconn.PgError{Code: "53300"}))
+  assert.Equal(t, true, PgxRetryable(&pgconn.PgError{Code: "08006"}))
+  assert.Equal(t, false, PgxRetryable(&pgconn.PgError{Code: "23505"}))
+
+  // Query may be executed by the server before network failure
+  netErr := fmt.Errorf("query: %w", &net.OpError{Op: "read", Err: errTest})
+  assert.Equal(t, false, PgxRetryable(netErr))
+  assert.Equal(t, true, PgxIdempotentRetryable(netErr))
+
+  assert.Equal(t, false, PgxRetryable(context.DeadlineExceeded))
+  assert.Equal(t, false, PgxIdempotentRetryable(context.DeadlineExceeded))
+
+  assert.Equal(t, true, PgxRetryable(fmt.Errorf("query: %w", safeToRetryError{})))
+}
+
+type safeToRetryError struct{}
+
+func (safeToRetryError) Error() string {
+  return "safe to retry"
+}
+
+func (safeToRetryError) SafeToRetry() bool {
+  return true
+}
+
+func Test_Backoffs(t *testing.T) {
+  exponential := ExponentialBackoff(10*time.Millisecond, 2)
+  assert.Equal(t, 40*time.Millisecond, exponential(3, 0))
+
+  capped := CappedBackoff(exponential, 25*time.Millisecond)
+  assert.Equal(t, 25*time.Millisecond, capped(3, 0))
+
+  jitter := DecorrelatedJitterBackoff(10*time.Millisecond, time.Second)
+
+  for prev, i := time.Duration(0), 0; i < 10; i++ {
+    wait := jitter(i+1, prev)
+    assert.Equal(t, true, wait >= 10*time.Millisecond && wait <= time.Second)
+    prev = wait
+  }
+}
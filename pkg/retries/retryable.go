@@ -0,0 +1,119 @@
+package retries
+
+import (
+  "context"
+  "errors"
+  "net"
+  "strings"
+
+  "github.com/jackc/pgx/v5/pgconn"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// Retryable decides whether the call failed with err can be retried
+type Retryable func(err error) bool
+
+type permanentError struct {
+  err error
+}
+
+func (e *permanentError) Error() string {
+  return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+  return e.err
+}
+
+// Permanent marks err as not retryable for any classifier
+func Permanent(err error) error {
+  if err == nil {
+    return nil
+  }
+  return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+  var permanent *permanentError
+  return errors.As(err, &permanent)
+}
+
+func AlwaysRetryable(err error) bool {
+  return !errors.Is(err, context.Canceled)
+}
+
+// AnyRetryable retries err if any of classifiers retries it
+func AnyRetryable(retryables ...Retryable) Retryable {
+  return func(err error) bool {
+    for _, retryable := range retryables {
+      if retryable(err) {
+        return true
+      }
+    }
+    return false
+  }
+}
+
+var grpcRetryableCodes = map[codes.Code]struct{}{
+  codes.Unavailable:       {},
+  codes.DeadlineExceeded:  {},
+  codes.ResourceExhausted: {},
+  codes.Aborted:           {},
+}
+
+func GrpcRetryable(err error) bool {
+  if _, ok := status.FromError(err); !ok {
+    return false
+  }
+  _, ok := grpcRetryableCodes[status.Code(err)]
+  return ok
+}
+
+var pgRetryableCodes = map[string]struct{}{
+  // serialization_failure
+  "40001": {},
+  // deadlock_detected
+  "40P01": {},
+  // cannot_connect_now while the database system is starting up
+  "57P03": {},
+  // too_many_connections
+  "53300": {},
+}
+
+const (
+  // Connection exception class
+  pgConnectionExceptionClass = "08"
+)
+
+// PgxRetryable retries server errors of the aborted statements and errors
+// occurred before sending any data to the server, safe for not idempotent calls
+func PgxRetryable(err error) bool {
+  var pgErr *pgconn.PgError
+
+  if errors.As(err, &pgErr) {
+    if _, ok := pgRetryableCodes[pgErr.Code]; ok {
+      return true
+    }
+    return strings.HasPrefix(pgErr.Code, pgConnectionExceptionClass)
+  }
+  // Wrapped errors are not checked by pgconn.SafeToRetry
+  var safeErr interface{ SafeToRetry() bool }
+
+  return errors.As(err, &safeErr) && safeErr.SafeToRetry()
+}
+
+// PgxIdempotentRetryable retries network failures and timeouts in addition to PgxRetryable,
+// the call may be already executed by the server, use it for idempotent calls like Ping only
+func PgxIdempotentRetryable(err error) bool {
+  if PgxRetryable(err) {
+    return true
+  }
+  // Context errors are net errors too
+  if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+    return false
+  }
+  var netErr net.Error
+
+  return errors.As(err, &netErr) || pgconn.Timeout(err)
+}
@@ -3,6 +3,7 @@ package executor
 import (
   "context"
   "fmt"
+  "time"
 
   "github.com/georgysavva/scany/v2/pgxscan"
   "github.com/jackc/pgx/v5"
@@ -46,7 +47,13 @@ func NewExecutor(ctx context.Context, dsn string) (Executor, error) {
   }
 
   err = retries.DoWithRetries(ctx,
-    retries.Options{},
+    retries.Options{
+      Backoff: retries.CappedBackoff(
+        retries.ExponentialBackoff(100*time.Millisecond, 2),
+        2*time.Second,
+      ),
+      Retryable: retries.PgxIdempotentRetryable,
+    },
 
     func(ctx context.Context) error {
       if err = pool.Ping(ctx); err != nil {
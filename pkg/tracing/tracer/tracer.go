@@ -55,9 +55,7 @@ func SpanFromContext(ctx context.Context) trace.Span {
 
 func InitTracer(ctx context.Context, serviceName, serviceVer string) (shutdowns []func(ctx context.Context) error) {
   once.Do(func() {
-    endpoint := env.Get(env.JaegerEndpointKey).
-      OrDefault(env.JaegerEndpointDefault).
-      String()
+    endpoint := env.Boiler().JaegerEndpoint
 
     exporter, err := otlptracehttp.New(ctx,
       otlptracehttp.WithInsecure(),
@@ -44,27 +44,55 @@ const (
   StorageFilterIfStmtWithPtr = "filters.%s.Ptr() != nil"
   // StorageFilterIfStmtWithLen const for compiled Boiler build with filter if statement for slice typed filters
   StorageFilterIfStmtWithLen = "len(filters.%s) > 0"
+  // StorageFilterIfStmtWithValid const for compiled Boiler build with filter if statement for pgtype typed filters
+  StorageFilterIfStmtWithValid = "filters.%s.Valid"
+  // StorageFilterIfStmtWithNil const for compiled Boiler build with filter if statement for pointer typed filters
+  StorageFilterIfStmtWithNil = "filters.%s != nil"
 
   // StorageInputIfStmtWithPtr const for compiled Boiler build with input statement for zero typed fields
   StorageInputIfStmtWithPtr = "input.%s.Ptr() != nil"
   // StorageInputIfStmtWithLen const for compiled Boiler build with input statement for slice typed fields
   StorageInputIfStmtWithLen = "len(input.%s) > 0"
+  // StorageInputIfStmtWithValid const for compiled Boiler build with input statement for pgtype typed fields
+  StorageInputIfStmtWithValid = "input.%s.Valid"
+  // StorageInputIfStmtWithIsValid const for compiled Boiler build with input statement for netip typed fields
+  StorageInputIfStmtWithIsValid = "input.%s.IsValid()"
+  // StorageInputIfStmtWithNil const for compiled Boiler build with input statement for pointer typed fields
+  StorageInputIfStmtWithNil = "input.%s != nil"
+
+  // StorageModelNullStmtWithValid const for compiled Boiler build with null statement for zero typed model fields
+  StorageModelNullStmtWithValid = "!model.%s.Valid"
+  // StorageModelNullStmtWithNil const for compiled Boiler build with null statement for slice typed model fields
+  StorageModelNullStmtWithNil = "model.%s == nil"
+  // StorageModelNullStmtWithIsValid const for compiled Boiler build with null statement for netip typed model fields
+  StorageModelNullStmtWithIsValid = "!model.%s.IsValid()"
+
+  // StorageModelUpsertSuffix const for compiled Boiler build with conflict target and update columns of the upsert
+  StorageModelUpsertSuffix = "ON CONFLICT (%s) DO UPDATE SET %s"
+  // StorageModelUpsertSet const for compiled Boiler build with update column of the upsert
+  StorageModelUpsertSet = "%[1]s = EXCLUDED.%[1]s"
 
   // StorageConsts ...
   StorageConsts = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\n{{- $count := len .Models}}\n{{- if ne $count 0 }}\ntype tableName string\n\nconst (\n  {{- range .Models}}\n  {{.ModelName}}_TableName tableName = \"{{.SqlTableName}}\"\n  {{- end}}\n)\n\ntype (\n  {{- range .Models}}\n  {{toLowerCamelCase .ModelName}}_Field string\n  {{- end}}\n)\n\n{{- range $m := .Models}}\nconst (\n  {{- range .ModelFields}}\n  {{.FieldName}}_{{$m.ModelName}}_Field {{toLowerCamelCase $m.ModelName}}_Field = \"{{.SqlTableFieldName}}\"\n  {{- end}}\n)\n{{end}}\n{{- end}}\n"
+  // StorageEnums ...
+  StorageEnums = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n{{- if .Enums}}\n\nimport (\n  {{- range .EnumsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Enums of the models used in filters and inputs\ntype (\n  {{- range .Enums}}\n  {{.EnumName}}     = models.{{.EnumName}}\n  {{.NullEnumName}} = models.{{.NullEnumName}}\n  {{- end}}\n)\n{{- end}}\n"
+  // StorageModelEnums ...
+  StorageModelEnums = "// Code generated by Boiler; DO NOT EDIT.\n\npackage models\n{{- if .Enums}}\n\nimport (\n  {{- range .ModelEnumsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n{{- end}}\n{{- range $enum := .Enums}}\n\n// {{.EnumName}} values of the {{.SqlTypeName}} enum type\ntype {{.EnumName}} string\n\nconst (\n  {{- range .EnumValues}}\n  {{.ValueName}} {{$enum.EnumName}} = {{printf \"%q\" .Value}}\n  {{- end}}\n)\n\nfunc {{.EnumName}}Values() []{{.EnumName}} {\n  return []{{.EnumName}}{\n    {{- range .EnumValues}}\n    {{.ValueName}},\n    {{- end}}\n  }\n}\n\nfunc (e {{.EnumName}}) Valid() bool {\n  for _, value := range {{.EnumName}}Values() {\n    if e == value {\n      return true\n    }\n  }\n  return false\n}\n\nfunc (e *{{.EnumName}}) Scan(src any) error {\n  switch src := src.(type) {\n  case string:\n    *e = {{.EnumName}}(src)\n  case []byte:\n    *e = {{.EnumName}}(src)\n  default:\n    return fmt.Errorf(\"{{.EnumName}}: unsupported scan type: %T\", src)\n  }\n  return nil\n}\n\nfunc (e {{.EnumName}}) Value() (driver.Value, error) {\n  if !e.Valid() {\n    return nil, fmt.Errorf(\"{{.EnumName}}: invalid value: %q\", string(e))\n  }\n  return string(e), nil\n}\n\n// {{.NullEnumName}} is a nullable {{.EnumName}}, invalid value is null\ntype {{.NullEnumName}} struct {\n  {{.EnumName}} {{.EnumName}}\n  Valid bool\n}\n\nfunc New{{.NullEnumName}}(e {{.EnumName}}, valid bool) {{.NullEnumName}} {\n  return {{.NullEnumName}}{\n    {{.EnumName}}: e,\n    Valid: valid,\n  }\n}\n\nfunc {{.NullEnumName}}From(e {{.EnumName}}) {{.NullEnumName}} {\n  return New{{.NullEnumName}}(e, true)\n}\n\nfunc (e {{.NullEnumName}}) Ptr() *{{.EnumName}} {\n  if !e.Valid {\n    return nil\n  }\n  return &e.{{.EnumName}}\n}\n\nfunc (e *{{.NullEnumName}}) Scan(src any) error {\n  if src == nil {\n    *e = {{.NullEnumName}}{}\n    return nil\n  }\n  e.Valid = true\n  return e.{{.EnumName}}.Scan(src)\n}\n\nfunc (e {{.NullEnumName}}) Value() (driver.Value, error) {\n  if !e.Valid {\n    return nil, nil\n  }\n  return e.{{.EnumName}}.Value()\n}\n\nfunc (e {{.NullEnumName}}) MarshalJSON() ([]byte, error) {\n  if !e.Valid {\n    return []byte(\"null\"), nil\n  }\n  return json.Marshal(e.{{.EnumName}})\n}\n\nfunc (e *{{.NullEnumName}}) UnmarshalJSON(data []byte) error {\n  if string(data) == \"null\" {\n    *e = {{.NullEnumName}}{}\n    return nil\n  }\n  e.Valid = true\n  return json.Unmarshal(data, &e.{{.EnumName}})\n}\n{{- end}}\n"
   // StorageModelMethods ...
-  StorageModelMethods = "// Code generated by Boiler; DO NOT EDIT. {{$modelName := .ModelName}}\n\npackage storage\n\nimport (\n  {{- range .ModelMethodsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\nfunc (s *Storage) List{{$modelName}}(ctx context.Context, input List{{$modelName}}Input) ([]*models.{{$modelName}}, error) {\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range .ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName))\n\n  if err := input.Pagination.validate(); err != nil {\n    return nil, fmt.Errorf(\"pagination.Validate: %w\", err)\n  }\n\n  offset, limit := input.Pagination.orDefault().toOffsetLimit()\n  builder = builder.Offset(offset).Limit(limit)\n\n  if input.Sort != nil {\n    builder = builder.OrderBy(input.Sort.{{toLowerCamelCase $modelName}}Sort())\n  }\n\n  if filters := input.Filters; filters != nil {\n    {{- range $modelField := .ModelFields}}\n    {{- range .ModelsFieldFilters}}\n    if {{.FilterIfStmt}} {\n      builder = builder.Where(sq.{{.FilterSqOperator}}{string({{$modelField.FieldName}}_{{$modelName}}_Field): filters.{{.FilterName}}{{.FilterTypeSuffix}}})\n    }\n    {{- end}}\n    {{- end}}\n  }\n\n  return pg.SelectCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n}\n\nfunc (s *Storage) {{$modelName}}(ctx context.Context, input {{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range .ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName))\n\n  if filters := input.Filters; filters != nil {\n    {{- range $modelField := .ModelFields}}\n    {{- range .ModelFieldFilters}}\n    if {{.FilterIfStmt}} {\n      builder = builder.Where(sq.Eq{string({{$modelField.FieldName}}_{{$modelName}}_Field): filters.{{.FilterName}}{{.FilterTypeSuffix}}})\n    }\n    {{- end}}\n    {{- end}}\n  }\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n\nfunc (s *Storage) Create{{$modelName}}(ctx context.Context, input Create{{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewInsertBuilder().\n    Into(string({{$modelName}}_TableName))\n\n  fields := map[string]any{\n    {{- range .ModelFields}}\n    {{- if eq .NotNullField true}}\n    {{- if eq .WithDefaultField false}}\n    string({{.FieldName}}_{{$modelName}}_Field): input.{{.FieldName}},\n    {{- end}}\n    {{- end}}\n    {{- end}}\n  }\n\n  {{- range $modelField := .ModelFields}}\n  {{- if eq .NotNullField false}}\n  {{- if eq .WithDefaultField false}}\n  if {{.FieldIfStmt}} {\n    fields[string({{$modelField.FieldName}}_{{$modelName}}_Field)] = input.{{.FieldName}}{{.FieldTypeSuffix}}\n  }\n  {{- end}}\n  {{- end}}\n  {{- end}}\n\n  builder = builder.SetMap(fields).\n    Suffix(suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n\nfunc (s *Storage) Update{{$modelName}}(ctx context.Context, input Update{{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewUpdateBuilder().\n    Table(string({{$modelName}}_TableName))\n\n  fields := map[string]any{}\n\n  {{- range .ModelFields}}\n  {{- if ne .FieldBadge \"pk\"}}\n  if {{.FieldZeroTypeIfStmt}} {\n    fields[string({{.FieldName}}_{{$modelName}}_Field)] = input.{{.FieldName}}{{.FieldZeroTypeSuffix}}\n  }\n  {{- end}}\n  {{- end}}\n\n  builder = builder.\n    SetMap(fields).\n    {{- range .ModelFields}}\n    {{- if eq .FieldBadge \"pk\"}}\n    Where(sq.Eq{string({{.FieldName}}_{{$modelName}}_Field): input.{{.FieldName}}}).\n    {{- end}}\n    {{- end}}\n    Suffix(suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n\nfunc (s *Storage) Delete{{$modelName}}(ctx context.Context, input Delete{{$modelName}}Input) (*models.{{.ModelName}}, error) {\n  builder := br.NewDeleteBuilder().\n    From(string({{$modelName}}_TableName)).\n    {{- range .ModelFields}}\n    {{- if eq .FieldBadge \"pk\"}}\n    Where(sq.Eq{string({{.FieldName}}_{{$modelName}}_Field): input.{{.FieldName}}}).\n    {{- end}}\n    {{- end}}\n    Suffix(suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n"
+  StorageModelMethods = "// Code generated by Boiler; DO NOT EDIT. {{$modelName := .ModelName}}\n\npackage storage\n\nimport (\n  {{- range .ModelMethodsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Suppress unused imports\nvar (\n  _ = zero.Time{}\n  _ = time.Time{}\n)\n\n{{- if .ModelMethods.List}}\n\nfunc (s *Storage) List{{$modelName}}(ctx context.Context, input List{{$modelName}}Input) ([]*models.{{$modelName}}, error) {\n  builder, err := new{{$modelName}}PageBuilder(input)\n  if err != nil {\n    return nil, err\n  }\n  {{- if .ModelEagerRelations}}\n\n  list, err := pg.SelectCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  if err = s.load{{$modelName}}Relations(ctx, list, input.Relations); err != nil {\n    return nil, fmt.Errorf(\"s.load{{$modelName}}Relations: %w\", err)\n  }\n  return list, nil\n  {{- else}}\n\n  return pg.SelectCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  {{- end}}\n}\n\ntype {{toLowerCamelCase $modelName}}WithTotal struct {\n  models.{{$modelName}}\n  TotalCount uint64 `db:\"total_count\"`\n}\n\n// List{{$modelName}}WithTotal lists models as List{{$modelName}} with total count of the filtered models\n// in the same query, total of the page after the last models is counted with Count{{$modelName}}\nfunc (s *Storage) List{{$modelName}}WithTotal(ctx context.Context, input List{{$modelName}}Input) ([]*models.{{$modelName}}, uint64, error) {\n  builder, err := new{{$modelName}}PageBuilder(input)\n  if err != nil {\n    return nil, 0, err\n  }\n  builder = builder.Column(\"count(*) OVER() AS total_count\")\n\n  rows, err := pg.SelectCtx[*{{toLowerCamelCase $modelName}}WithTotal](ctx, s.executor, builder)\n  if err != nil {\n    return nil, 0, err\n  }\n  if len(rows) == 0 {\n    total, err := s.Count{{$modelName}}(ctx, input.Filters)\n    if err != nil {\n      return nil, 0, fmt.Errorf(\"s.Count{{$modelName}}: %w\", err)\n    }\n    return nil, total, nil\n  }\n  list := make([]*models.{{$modelName}}, 0, len(rows))\n\n  for _, row := range rows {\n    list = append(list, &row.{{$modelName}})\n  }\n  {{- if .ModelEagerRelations}}\n  if err = s.load{{$modelName}}Relations(ctx, list, input.Relations); err != nil {\n    return nil, 0, fmt.Errorf(\"s.load{{$modelName}}Relations: %w\", err)\n  }\n  {{- end}}\n  return list, rows[0].TotalCount, nil\n}\n\nfunc new{{$modelName}}PageBuilder(input List{{$modelName}}Input) (sq.SelectBuilder, error) {\n  builder := new{{$modelName}}ListBuilder(input.Filters)\n\n  if err := input.Pagination.validate(); err != nil {\n    return builder, fmt.Errorf(\"pagination.Validate: %w\", err)\n  }\n\n  offset, limit := input.Pagination.orDefault().toOffsetLimit()\n  builder = builder.Offset(offset).Limit(limit)\n\n  if input.Sort != nil {\n    builder = builder.OrderBy(input.Sort.{{toLowerCamelCase $modelName}}Sort())\n  }\n  return builder, nil\n}\n\ntype List{{$modelName}}Output struct {\n  Models []*models.{{$modelName}}\n  // Cursor of the next page, empty for the last page\n  NextCursor string\n}\n\n// List{{$modelName}}ByCursor lists models after the input cursor ordered by the sort field and primary key,\n// default sort is by primary key ascending, pagination page is not used\nfunc (s *Storage) List{{$modelName}}ByCursor(ctx context.Context, input List{{$modelName}}Input) (*List{{$modelName}}Output, error) {\n  if err := input.Pagination.validateCursor(); err != nil {\n    return nil, fmt.Errorf(\"pagination.validateCursor: %w\", err)\n  }\n  sort := input.Sort\n\n  if sort == nil {\n    sort = &List{{$modelName}}Sort{\n      Field: {{(index .ModelPkFields 0).FieldName}}_{{$modelName}}_Field,\n      Order: SortOrderAsc,\n    }\n  }\n  if err := validateCursorOrder(sort.Order); err != nil {\n    return nil, err\n  }\n  perPage := input.Pagination.orDefault().PerPage\n\n  builder := new{{$modelName}}ListBuilder(input.Filters).\n    OrderBy(keysetOrderBy(string(sort.Field), sort.Order, {{toLowerCamelCase $modelName}}KeyColumns)...).\n    // Extra model for the next page check\n    Limit(perPage + 1)\n\n  if input.Cursor != \"\" {\n    c, err := decodeCursor(input.Cursor)\n    if err != nil {\n      return nil, err\n    }\n    if c.Field != string(sort.Field) || c.Order != sort.Order {\n      return nil, fmt.Errorf(\"cursor sort %s %s does not match input sort %s %s\", c.Field, c.Order, sort.Field, sort.Order)\n    }\n    value, err := decode{{$modelName}}CursorValue(sort.Field, c.Value)\n    if err != nil {\n      return nil, fmt.Errorf(\"invalid cursor value: %w\", err)\n    }\n    var key models.{{$modelName}}Key\n\n    if err = json.Unmarshal(c.Key, &key); err != nil {\n      return nil, fmt.Errorf(\"invalid cursor key: %w\", err)\n    }\n    builder = builder.Where(keysetWhere(string(sort.Field), sort.Order, value, c.valueNull(),\n      {{toLowerCamelCase $modelName}}KeyColumns, {{toLowerCamelCase $modelName}}KeyValues(key)))\n  }\n\n  list, err := pg.SelectCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  output := &List{{$modelName}}Output{\n    Models: list,\n  }\n  if uint64(len(list)) > perPage {\n    output.Models = list[:perPage]\n    last := output.Models[perPage-1]\n\n    output.NextCursor, err = encodeCursor(string(sort.Field), sort.Order, {{toLowerCamelCase $modelName}}CursorValue(last, sort.Field), last.Key())\n    if err != nil {\n      return nil, fmt.Errorf(\"encodeCursor: %w\", err)\n    }\n  }\n  {{- if .ModelEagerRelations}}\n  if err = s.load{{$modelName}}Relations(ctx, output.Models, input.Relations); err != nil {\n    return nil, fmt.Errorf(\"s.load{{$modelName}}Relations: %w\", err)\n  }\n  {{- end}}\n  return output, nil\n}\n\n// Count{{$modelName}} returns count of the models matched by the filters, nil filters count all models\nfunc (s *Storage) Count{{$modelName}}(ctx context.Context, filters *List{{$modelName}}Filters) (uint64, error) {\n  builder := br.NewSelectBuilder().\n    Columns(\"count(*)\").\n    From(string({{$modelName}}_TableName))\n\n  if where := filters.where(); len(where) > 0 {\n    builder = builder.Where(where)\n  }\n  return pg.GetCtx[uint64](ctx, s.executor, builder)\n}\n\n// Exists{{$modelName}} reports if any model is matched by the filters\nfunc (s *Storage) Exists{{$modelName}}(ctx context.Context, filters *List{{$modelName}}Filters) (bool, error) {\n  builder := br.NewSelectBuilder().\n    Prefix(\"SELECT EXISTS (\").\n    Columns(\"1\").\n    From(string({{$modelName}}_TableName)).\n    Suffix(\")\")\n\n  if where := filters.where(); len(where) > 0 {\n    builder = builder.Where(where)\n  }\n  return pg.GetCtx[bool](ctx, s.executor, builder)\n}\n\n// Aggregate{{$modelName}} returns count and aggregates of the filtered models by groups ordered by the group field\nfunc (s *Storage) Aggregate{{$modelName}}(ctx context.Context, input Aggregate{{$modelName}}Input) ([]*Aggregate{{$modelName}}Output, error) {\n  builder := br.NewSelectBuilder().\n    Columns(\"count(*) AS count\").\n    From(string({{$modelName}}_TableName))\n  {{- if .ModelAggregateFields}}\n\n  fieldsByFunctions := map[string][]{{toLowerCamelCase $modelName}}_Field{\n    aggregateSum: input.Sum,\n    aggregateMin: input.Min,\n    aggregateMax: input.Max,\n  }\n  for _, function := range aggregateFunctions {\n    columns, err := aggregateColumns(function, fieldsByFunctions[function], {{toLowerCamelCase $modelName}}AggregateFields)\n    if err != nil {\n      return nil, err\n    }\n    builder = builder.Columns(columns...)\n  }\n  {{- end}}\n\n  if where := input.Filters.where(); len(where) > 0 {\n    builder = builder.Where(where)\n  }\n\n  switch input.GroupBy {\n  case \"\":\n  case {{range $i, $field := .ModelFields}}{{if $i}}, {{end}}{{$field.FieldName}}_{{$modelName}}_Field{{end}}:\n    builder = builder.\n      Column(fmt.Sprintf(\"%s AS group_value\", input.GroupBy)).\n      GroupBy(string(input.GroupBy)).\n      OrderBy(string(input.GroupBy))\n  default:\n    return nil, fmt.Errorf(\"unknown group by field: %s\", input.GroupBy)\n  }\n  return pg.SelectCtx[*Aggregate{{$modelName}}Output](ctx, s.executor, builder)\n}\n{{- if .ModelAggregateFields}}\n\n// {{toLowerCamelCase $modelName}}AggregateFields are the numeric fields aggregated with Aggregate{{$modelName}}\nvar {{toLowerCamelCase $modelName}}AggregateFields = []{{toLowerCamelCase $modelName}}_Field{\n  {{- range .ModelAggregateFields}}\n  {{.FieldName}}_{{$modelName}}_Field,\n  {{- end}}\n}\n{{- end}}\n{{- end}}\n\nfunc new{{$modelName}}ListBuilder(filters *List{{$modelName}}Filters) sq.SelectBuilder {\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range .ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName))\n\n  if where := filters.where(); len(where) > 0 {\n    builder = builder.Where(where)\n  }\n  return builder\n}\n\n// where returns conditions of the filters and nested filters, nil filters match all models\nfunc (filters *List{{$modelName}}Filters) where() sq.And {\n  if filters == nil {\n    return nil\n  }\n  var where sq.And\n\n  {{- range $modelField := .ModelFields}}\n  {{- range .ModelsFieldFilters}}\n  if {{.FilterIfStmt}} {\n    {{- if .FilterSqFunc}}\n    where = append(where, {{.FilterSqFunc}}(string({{$modelField.FieldName}}_{{$modelName}}_Field), filters.{{.FilterName}}{{.FilterTypeSuffix}}))\n    {{- else}}\n    where = append(where, sq.{{.FilterSqOperator}}{string({{$modelField.FieldName}}_{{$modelName}}_Field): filters.{{.FilterName}}{{.FilterTypeSuffix}}})\n    {{- end}}\n  }\n  {{- end}}\n  {{- end}}\n\n  if len(filters.or) > 0 {\n    or := make(sq.Or, 0, len(filters.or))\n\n    for _, other := range filters.or {\n      or = append(or, other.where())\n    }\n    where = append(where, or)\n  }\n  for _, other := range filters.and {\n    if otherWhere := other.where(); len(otherWhere) > 0 {\n      where = append(where, otherWhere)\n    }\n  }\n  return where\n}\n\n{{- range .ModelLoaders}}\n\n// {{.LoaderName}} lists models by keys of the {{.ParentModelName}} models in one query\nfunc (s *Storage) {{.LoaderName}}(ctx context.Context, ids []{{.ChildField.FieldBuiltinType}}) ([]*models.{{$modelName}}, error) {\n  if len(ids) == 0 {\n    return nil, nil\n  }\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range $.ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName)).\n    Where(sq.Eq{string({{.ChildField.FieldName}}_{{$modelName}}_Field): ids}).\n    OrderBy(string({{.ChildField.FieldName}}_{{$modelName}}_Field)).\n    OrderBy({{toLowerCamelCase $modelName}}KeyColumns...)\n\n  return pg.SelectCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n}\n{{- end}}\n{{- if .ModelEagerRelations}}\n\nfunc (s *Storage) load{{$modelName}}Relations(ctx context.Context, list []*models.{{$modelName}}, relations *List{{$modelName}}Relations) error {\n  if relations == nil || len(list) == 0 {\n    return nil\n  }\n  {{- range .ModelEagerRelations}}\n\n  if relations.{{.EagerFieldName}} {\n    ids := make([]{{.ChildField.FieldBuiltinType}}, 0, len(list))\n\n    for _, model := range list {\n      ids = append(ids, {{.ParentChildKey}})\n    }\n    children, err := s.{{.LoaderName}}(ctx, ids)\n    if err != nil {\n      return fmt.Errorf(\"s.{{.LoaderName}}: %w\", err)\n    }\n    grouped := make(map[{{.ParentField.FieldType}}][]*models.{{.ChildModelName}}, len(list))\n\n    for _, model := range children {\n      {{- if .ChildField.FieldNullStmt}}\n      if {{.ChildField.FieldNullStmt}} {\n        continue\n      }\n      {{- end}}\n      key := {{.ChildParentKey}}\n      grouped[key] = append(grouped[key], model)\n    }\n    for _, model := range list {\n      model.{{.EagerFieldName}} = grouped[model.{{.ParentField.FieldName}}]\n    }\n  }\n  {{- end}}\n  return nil\n}\n{{- end}}\n\n// {{toLowerCamelCase $modelName}}KeyColumns are the primary key columns in the constraint order\nvar {{toLowerCamelCase $modelName}}KeyColumns = []string{\n  {{- range .ModelPkFields}}\n  string({{.FieldName}}_{{$modelName}}_Field),\n  {{- end}}\n}\n\nfunc {{toLowerCamelCase $modelName}}KeyValues(key models.{{$modelName}}Key) []any {\n  return []any{\n    {{- range .ModelPkFields}}\n    key.{{.FieldName}},\n    {{- end}}\n  }\n}\n\n// {{toLowerCamelCase $modelName}}CursorValue returns value of the model field, nil for null value\nfunc {{toLowerCamelCase $modelName}}CursorValue(model *models.{{$modelName}}, field {{toLowerCamelCase $modelName}}_Field) any {\n  switch field {\n  {{- range .ModelFields}}\n  case {{.FieldName}}_{{$modelName}}_Field:\n    {{- if .FieldNullStmt}}\n    if {{.FieldNullStmt}} {\n      return nil\n    }\n    {{- end}}\n    return model.{{.FieldName}}\n  {{- end}}\n  }\n  return nil\n}\n\nfunc decode{{$modelName}}CursorValue(field {{toLowerCamelCase $modelName}}_Field, raw json.RawMessage) (any, error) {\n  switch field {\n  {{- range .ModelFields}}\n  case {{.FieldName}}_{{$modelName}}_Field:\n    var value {{.FieldType}}\n    err := json.Unmarshal(raw, &value)\n    return value, err\n  {{- end}}\n  }\n  return nil, fmt.Errorf(\"unknown field: %s\", field)\n}\n\n{{- if .ModelMethods.Get}}\n\nfunc (s *Storage) {{$modelName}}(ctx context.Context, input {{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range .ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName))\n\n  if filters := input.Filters; filters != nil {\n    {{- range $modelField := .ModelFields}}\n    {{- range .ModelFieldFilters}}\n    if {{.FilterIfStmt}} {\n      builder = builder.Where(sq.Eq{string({{$modelField.FieldName}}_{{$modelName}}_Field): filters.{{.FilterName}}{{.FilterTypeSuffix}}})\n    }\n    {{- end}}\n    {{- end}}\n  }\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n{{- range .ModelUniqueKeys}}\n\nfunc (s *Storage) Get{{$modelName}}By{{.KeyName}}(ctx context.Context, input Get{{$modelName}}By{{.KeyName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range $.ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName)).\n    {{- range .KeyFields}}\n    Where(sq.Expr(string({{.FieldName}}_{{$modelName}}_Field)+\" = ?\", input.{{.FieldName}})).\n    {{- end}}\n    Limit(1)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n{{- end}}\n{{- if .ModelKeyComparable}}\n\n// Get{{$modelName}}ByKeys returns models found by the primary keys in one query\nfunc (s *Storage) Get{{$modelName}}ByKeys(ctx context.Context, keys []models.{{$modelName}}Key) (map[models.{{$modelName}}Key]*models.{{$modelName}}, error) {\n  if len(keys) == 0 {\n    return map[models.{{$modelName}}Key]*models.{{$modelName}}{}, nil\n  }\n  values := make([][]any, 0, len(keys))\n\n  for _, key := range keys {\n    values = append(values, {{toLowerCamelCase $modelName}}KeyValues(key))\n  }\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range .ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName)).\n    Where(keysWhere({{toLowerCamelCase $modelName}}KeyColumns, values))\n\n  list, err := pg.SelectCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  found := make(map[models.{{$modelName}}Key]*models.{{$modelName}}, len(list))\n\n  for _, model := range list {\n    found[model.Key()] = model\n  }\n  return found, nil\n}\n{{- end}}\n{{- end}}\n{{- if .ModelMethods.Create}}\n\nfunc (s *Storage) Create{{$modelName}}(ctx context.Context, input Create{{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewInsertBuilder().\n    Into(string({{$modelName}}_TableName)).\n    SetMap(create{{$modelName}}Fields(input)).\n    Suffix(suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n{{- end}}\n{{- range .ModelUpserts}}\n\nfunc (s *Storage) Upsert{{$modelName}}{{.UpsertName}}(ctx context.Context, input Upsert{{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewInsertBuilder().\n    Into(string({{$modelName}}_TableName)).\n    SetMap(create{{$modelName}}Fields(input)).\n    Suffix(\"{{.UpsertSuffix}} \" + suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n{{- end}}\n\nfunc create{{$modelName}}Fields(input Create{{$modelName}}Input) map[string]any {\n  fields := map[string]any{\n    {{- range .ModelFields}}\n    {{- if eq .NotNullField true}}\n    {{- if .CreateInputField}}\n    string({{.FieldName}}_{{$modelName}}_Field): input.{{.FieldName}},\n    {{- end}}\n    {{- end}}\n    {{- end}}\n  }\n\n  {{- range $modelField := .ModelFields}}\n  {{- if eq .NotNullField false}}\n  {{- if .CreateInputField}}\n  if {{.FieldIfStmt}} {\n    fields[string({{$modelField.FieldName}}_{{$modelName}}_Field)] = input.{{.FieldName}}{{.FieldTypeSuffix}}\n  }\n  {{- end}}\n  {{- end}}\n  {{- end}}\n\n  return fields\n}\n\n{{- if .ModelMethods.Update}}\n\nfunc (s *Storage) Update{{$modelName}}(ctx context.Context, input Update{{$modelName}}Input) (*models.{{$modelName}}, error) {\n  builder := br.NewUpdateBuilder().\n    Table(string({{$modelName}}_TableName))\n\n  fields := map[string]any{}\n\n  {{- range .ModelFields}}\n  {{- if .UpdateInputField}}\n  if {{.FieldZeroTypeIfStmt}} {\n    fields[string({{.FieldName}}_{{$modelName}}_Field)] = input.{{.FieldName}}{{.FieldZeroTypeSuffix}}\n  }\n  {{- end}}\n  {{- end}}\n\n  builder = builder.\n    SetMap(fields).\n    Where(keyWhere({{toLowerCamelCase $modelName}}KeyColumns, []any{\n      {{- range .ModelPkFields}}\n      input.{{.FieldName}},\n      {{- end}}\n    })).\n    Suffix(suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n{{- end}}\n{{- if .ModelMethods.Delete}}\n\nfunc (s *Storage) Delete{{$modelName}}(ctx context.Context, input Delete{{$modelName}}Input) (*models.{{.ModelName}}, error) {\n  builder := br.NewDeleteBuilder().\n    From(string({{$modelName}}_TableName)).\n    Where(keyWhere({{toLowerCamelCase $modelName}}KeyColumns, []any{\n      {{- range .ModelPkFields}}\n      input.{{.FieldName}},\n      {{- end}}\n    })).\n    Suffix(suffixReturning)\n\n  model, err := pg.GetCtx[*models.{{$modelName}}](ctx, s.executor, builder)\n  if err != nil {\n    return nil, err\n  }\n  return model, nil\n}\n{{- end}}\n\n// {{$modelName}}Columns typed columns of the {{$modelName}} model used in queries\nvar {{$modelName}}Columns = struct {\n  {{- range .ModelFields}}\n  {{.FieldName}} {{.FieldColumnType}}\n  {{- end}}\n}{\n  {{- range .ModelFields}}\n  {{.FieldName}}: {{.FieldColumnCtor}}(string({{.FieldName}}_{{$modelName}}_Field)),\n  {{- end}}\n}\n{{- if .ModelMethods.List}}\n\n// Select{{$modelName}}Query returns query of the {{$modelName}} models with conditions on {{$modelName}}Columns\nfunc (s *Storage) Select{{$modelName}}Query() *SelectQuery[models.{{$modelName}}] {\n  builder := br.NewSelectBuilder().\n    Columns(\n      {{- range .ModelFields}}\n      string({{.FieldName}}_{{$modelName}}_Field),\n      {{- end}}\n    ).\n    From(string({{$modelName}}_TableName))\n\n  return &SelectQuery[models.{{$modelName}}]{\n    executor: s.executor,\n    builder:  builder,\n  }\n}\n{{- end}}\n{{- if .ModelMethods.Update}}\n\n// Update{{$modelName}}Query returns query updating the {{$modelName}} models with conditions on {{$modelName}}Columns\nfunc (s *Storage) Update{{$modelName}}Query() *UpdateQuery[models.{{$modelName}}] {\n  builder := br.NewUpdateBuilder().\n    Table(string({{$modelName}}_TableName)).\n    Suffix(suffixReturning)\n\n  return &UpdateQuery[models.{{$modelName}}]{\n    executor: s.executor,\n    builder:  builder,\n  }\n}\n{{- end}}\n{{- if .ModelMethods.Delete}}\n\n// Delete{{$modelName}}Query returns query deleting the {{$modelName}} models with conditions on {{$modelName}}Columns\nfunc (s *Storage) Delete{{$modelName}}Query() *DeleteQuery[models.{{$modelName}}] {\n  builder := br.NewDeleteBuilder().\n    From(string({{$modelName}}_TableName)).\n    Suffix(suffixReturning)\n\n  return &DeleteQuery[models.{{$modelName}}]{\n    executor: s.executor,\n    builder:  builder,\n  }\n}\n{{- end}}\n"
   // StorageModelOptions ...
-  StorageModelOptions = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\nimport (\n  {{- range .ModelOptionsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Suppress unused imports\nvar (\n    _ = zero.Time{}\n    _ = time.Time{}\n)\n\ntype {{.ModelName}}Input struct {\n  Filters *{{.ModelName}}Filter\n}\n\ntype {{.ModelName}}Filter struct {\n  {{- range .ModelFields}}\n  {{- range .ModelFieldFilters}}\n  {{.FilterName}} {{.FilterType}}\n  {{- end}}\n  {{- end}}\n}\n\ntype Create{{.ModelName}}Input struct {\n  {{- range .ModelFields}}\n  {{- if eq .WithDefaultField false}}\n  {{.FieldName}} {{.FieldType}} {{- if eq .FieldBadge \"pk\"}} // PRIMARY KEY{{- end}}\n  {{- end}}\n  {{- end}}\n}\n\ntype Delete{{.ModelName}}Input struct {\n  {{- range .ModelFields}}\n  {{- if eq .FieldBadge \"pk\"}}\n  {{.FieldName}} {{.FieldType}} // PRIMARY KEY\n  {{- end}}\n  {{- end}}\n}\n\ntype Update{{.ModelName}}Input struct {\n  {{- range .ModelFields}}\n  {{- if eq .FieldBadge \"pk\"}}\n  {{.FieldName}} {{.FieldType}} // PRIMARY KEY\n  {{- else}}\n  {{.FieldName}} {{.FieldZeroType}}\n  {{- end}}\n  {{- end}}\n}\n\ntype List{{.ModelName}}Input struct {\n  Filters    *List{{.ModelName}}Filters\n  Sort       *List{{.ModelName}}Sort\n  Pagination *Pagination\n}\n\ntype List{{.ModelName}}Sort struct {\n  Field {{toLowerCamelCase .ModelName}}_Field\n  Order sortOrder\n}\n\ntype List{{.ModelName}}Filters struct {\n  {{- range .ModelFields}}\n  {{- range .ModelsFieldFilters}}\n  {{.FilterName}} {{.FilterType}}\n  {{- end}}\n  {{- end}}\n}\n\nfunc (p *List{{.ModelName}}Sort) {{toLowerCamelCase .ModelName}}Sort() string {\n  return fmt.Sprintf(\"%s %s\", p.Field, p.Order)\n}\n"
+  StorageModelOptions = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\nimport (\n  {{- range .ModelOptionsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Suppress unused imports\nvar (\n    _ = zero.Time{}\n    _ = time.Time{}\n)\n\ntype {{.ModelName}}Input struct {\n  Filters *{{.ModelName}}Filter\n}\n\ntype {{.ModelName}}Filter struct {\n  {{- range .ModelFields}}\n  {{- range .ModelFieldFilters}}\n  {{.FilterName}} {{.FilterType}}\n  {{- end}}\n  {{- end}}\n}\n\ntype Create{{.ModelName}}Input struct {\n  {{- range .ModelFields}}\n  {{- if .CreateInputField}}\n  {{.FieldName}} {{.FieldType}} {{- if eq .FieldBadge \"pk\"}} // PRIMARY KEY{{- end}}\n  {{- end}}\n  {{- end}}\n}\n\n// Upsert{{.ModelName}}Input update columns are set from the input including null values\ntype Upsert{{.ModelName}}Input = Create{{.ModelName}}Input\n\n{{- range .ModelUniqueKeys}}\n\ntype Get{{$.ModelName}}By{{.KeyName}}Input struct {\n  {{- range .KeyFields}}\n  {{.FieldName}} {{.FieldBuiltinType}}\n  {{- end}}\n}\n{{- end}}\n\ntype Delete{{.ModelName}}Input struct {\n  {{- range .ModelPkFields}}\n  {{.FieldName}} {{.FieldType}} // PRIMARY KEY\n  {{- end}}\n}\n\ntype Update{{.ModelName}}Input struct {\n  {{- range .ModelFields}}\n  {{- if eq .FieldBadge \"pk\"}}\n  {{.FieldName}} {{.FieldType}} // PRIMARY KEY\n  {{- else if .UpdateInputField}}\n  {{.FieldName}} {{.FieldZeroType}}\n  {{- end}}\n  {{- end}}\n}\n\ntype List{{.ModelName}}Input struct {\n  Filters    *List{{.ModelName}}Filters\n  Sort       *List{{.ModelName}}Sort\n  Pagination *Pagination\n  // Cursor from List{{.ModelName}}ByCursor output, empty for the first page\n  Cursor string\n  {{- if .ModelEagerRelations}}\n  // Relations loaded into the listed models\n  Relations *List{{.ModelName}}Relations\n  {{- end}}\n}\n{{- if .ModelEagerRelations}}\n\ntype List{{.ModelName}}Relations struct {\n  {{- range .ModelEagerRelations}}\n  {{.EagerFieldName}} bool\n  {{- end}}\n}\n{{- end}}\n\ntype List{{.ModelName}}Sort struct {\n  Field {{toLowerCamelCase .ModelName}}_Field\n  Order sortOrder\n}\n\ntype List{{.ModelName}}Filters struct {\n  {{- range .ModelFields}}\n  {{- range .ModelsFieldFilters}}\n  {{.FilterName}} {{.FilterType}}\n  {{- end}}\n  {{- end}}\n\n  // Filters combined with the filters above\n  or  []*List{{.ModelName}}Filters\n  and []*List{{.ModelName}}Filters\n}\n\n// Or returns filters matching these filters or any of the other filters\nfunc (f *List{{.ModelName}}Filters) Or(others ...*List{{.ModelName}}Filters) *List{{.ModelName}}Filters {\n  return &List{{.ModelName}}Filters{\n    or: append([]*List{{.ModelName}}Filters{f}, others...),\n  }\n}\n\n// And returns filters matching these filters and all of the other filters\nfunc (f *List{{.ModelName}}Filters) And(others ...*List{{.ModelName}}Filters) *List{{.ModelName}}Filters {\n  return &List{{.ModelName}}Filters{\n    and: append([]*List{{.ModelName}}Filters{f}, others...),\n  }\n}\n\nfunc (p *List{{.ModelName}}Sort) {{toLowerCamelCase .ModelName}}Sort() string {\n  return fmt.Sprintf(\"%s %s\", p.Field, p.Order)\n}\n\ntype Aggregate{{.ModelName}}Input struct {\n  Filters *List{{.ModelName}}Filters\n  {{- if .ModelAggregateFields}}\n  // Numeric fields aggregated with the functions, not aggregated output fields are null\n  Sum []{{toLowerCamelCase .ModelName}}_Field\n  Min []{{toLowerCamelCase .ModelName}}_Field\n  Max []{{toLowerCamelCase .ModelName}}_Field\n  {{- end}}\n  // Field of the groups, models are aggregated in one group for empty field\n  GroupBy {{toLowerCamelCase .ModelName}}_Field\n}\n\ntype Aggregate{{.ModelName}}Output struct {\n  // Value of the group field, nil without group field\n  Group any    `db:\"group_value\"`\n  Count uint64 `db:\"count\"`\n  {{- range .ModelAggregateFields}}\n  {{.FieldName}}Sum {{.FieldZeroType}} `db:\"{{.SqlTableFieldName}}_sum\"`\n  {{.FieldName}}Min {{.FieldZeroType}} `db:\"{{.SqlTableFieldName}}_min\"`\n  {{.FieldName}}Max {{.FieldZeroType}} `db:\"{{.SqlTableFieldName}}_max\"`\n  {{- end}}\n}\n"
   // StorageModel ...
-  StorageModel = "// Code generated by Boiler; DO NOT EDIT.\n\npackage models\n\nimport (\n  {{- range .ModelPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Suppress unused imports\nvar (\n    _ = zero.Time{}\n    _ = time.Time{}\n)\n\ntype {{.ModelName}} struct {\n  {{- range .ModelFields}}\n  {{.FieldName}} {{.FieldType}} `db:\"{{.SqlTableFieldName}}\"` {{- if eq .FieldBadge \"pk\"}} // PRIMARY KEY{{- end}}\n  {{- end}}\n}\n"
+  StorageModel = "// Code generated by Boiler; DO NOT EDIT.\n\npackage models\n\nimport (\n  {{- range .ModelPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Suppress unused imports\nvar (\n    _ = zero.Time{}\n    _ = time.Time{}\n)\n\ntype {{.ModelName}} struct {\n  {{- range .ModelFields}}\n  {{.FieldName}} {{.FieldType}} `db:\"{{.SqlTableFieldName}}\"` {{- if eq .FieldBadge \"pk\"}} // PRIMARY KEY{{- end}}\n  {{- end}}\n  {{- range .ModelEagerRelations}}\n  {{.EagerFieldName}} []*{{.ChildModelName}} `db:\"-\"` // Loaded with List{{$.ModelName}}Relations\n  {{- end}}\n}\n\n// {{.ModelName}}Key is the primary key of the {{.ModelName}}\ntype {{.ModelName}}Key struct {\n  {{- range .ModelPkFields}}\n  {{.FieldName}} {{.FieldType}} `json:\"{{.SqlTableFieldName}}\"`\n  {{- end}}\n}\n\nfunc (m *{{.ModelName}}) Key() {{.ModelName}}Key {\n  return {{.ModelName}}Key{\n    {{- range .ModelPkFields}}\n    {{.FieldName}}: m.{{.FieldName}},\n    {{- end}}\n  }\n}\n"
   // StorageOptions ...
-  StorageOptions = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\nimport (\n  {{- range .OptionsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\nconst suffixReturning = \"RETURNING *\"\n\ntype sortOrder string\n\nconst (\n  SortOrderAsc  sortOrder = \"ASC\"\n  SortOrderDesc sortOrder = \"DESC\"\n  SortOrderRand sortOrder = \"RAND()\"\n)\n\ntype Pagination struct {\n  Page    uint64\n  PerPage uint64\n}\n\nfunc (p *Pagination) orDefault() *Pagination {\n  if p != nil {\n    return p\n  }\n  return &Pagination{\n    Page:    0,\n    PerPage: 100,\n  }\n}\n\nfunc (p *Pagination) validate() error {\n  if p == nil {\n    return nil\n  }\n  if p.Page < 0 {\n    return fmt.Errorf(\"pagination.Page=%d must be non-negative\", p.Page)\n  }\n  if p.PerPage < 0 {\n    return fmt.Errorf(\"pagination.PerPage=%d must be positive\", p.PerPage)\n  }\n  return nil\n}\n\nfunc (p *Pagination) toOffsetLimit() (offset uint64, limit uint64) {\n  offset = p.Page * p.PerPage\n  limit = p.PerPage\n  return offset, limit\n}\n\n"
+  StorageOptions = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\nimport (\n  {{- range .OptionsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\nconst suffixReturning = \"RETURNING *\"\n\ntype sortOrder string\n\nconst (\n  SortOrderAsc  sortOrder = \"ASC\"\n  SortOrderDesc sortOrder = \"DESC\"\n  SortOrderRand sortOrder = \"RAND()\"\n)\n\ntype Pagination struct {\n  Page    uint64\n  PerPage uint64\n}\n\nfunc (p *Pagination) orDefault() *Pagination {\n  if p != nil {\n    return p\n  }\n  return &Pagination{\n    Page:    0,\n    PerPage: 100,\n  }\n}\n\nfunc (p *Pagination) validate() error {\n  if p == nil {\n    return nil\n  }\n  if p.Page < 0 {\n    return fmt.Errorf(\"pagination.Page=%d must be non-negative\", p.Page)\n  }\n  if p.PerPage < 0 {\n    return fmt.Errorf(\"pagination.PerPage=%d must be positive\", p.PerPage)\n  }\n  return nil\n}\n\nfunc (p *Pagination) toOffsetLimit() (offset uint64, limit uint64) {\n  offset = p.Page * p.PerPage\n  limit = p.PerPage\n  return offset, limit\n}\n\n// validateCursor validates pagination of the listing by cursor\nfunc (p *Pagination) validateCursor() error {\n  if err := p.validate(); err != nil {\n    return err\n  }\n  if p == nil {\n    return nil\n  }\n  if p.Page != 0 {\n    return fmt.Errorf(\"pagination.Page=%d not supported with cursor\", p.Page)\n  }\n  if p.PerPage == 0 {\n    return fmt.Errorf(\"pagination.PerPage must be positive\")\n  }\n  return nil\n}\n\n// cursor of the keyset pagination with values of the last row\n// sort field and primary key, encoded cursor is opaque for callers\ntype cursor struct {\n  Field string          `json:\"f\"`\n  Order sortOrder       `json:\"o\"`\n  Value json.RawMessage `json:\"v\"`\n  Key   json.RawMessage `json:\"k\"`\n}\n\nfunc encodeCursor(field string, order sortOrder, value, key any) (string, error) {\n  c := cursor{\n    Field: field,\n    Order: order,\n  }\n  var err error\n\n  if c.Value, err = json.Marshal(value); err != nil {\n    return \"\", fmt.Errorf(\"json.Marshal: %w\", err)\n  }\n  if c.Key, err = json.Marshal(key); err != nil {\n    return \"\", fmt.Errorf(\"json.Marshal: %w\", err)\n  }\n  buf, err := json.Marshal(c)\n  if err != nil {\n    return \"\", fmt.Errorf(\"json.Marshal: %w\", err)\n  }\n  return base64.RawURLEncoding.EncodeToString(buf), nil\n}\n\nfunc decodeCursor(encoded string) (*cursor, error) {\n  buf, err := base64.RawURLEncoding.DecodeString(encoded)\n  if err != nil {\n    return nil, fmt.Errorf(\"invalid cursor: %w\", err)\n  }\n  c := &cursor{}\n\n  if err = json.Unmarshal(buf, c); err != nil {\n    return nil, fmt.Errorf(\"invalid cursor: %w\", err)\n  }\n  return c, nil\n}\n\nfunc (c *cursor) valueNull() bool {\n  return string(c.Value) == \"null\"\n}\n\nfunc validateCursorOrder(order sortOrder) error {\n  if order != SortOrderAsc && order != SortOrderDesc {\n    return fmt.Errorf(\"sort order %s not supported with cursor\", order)\n  }\n  return nil\n}\n\n// keysetOrderBy returns order by the sort column and primary key columns,\n// rows with null values of the sort column are the last\nfunc keysetOrderBy(column string, order sortOrder, pkColumns []string) []string {\n  orderBy := make([]string, 0, len(pkColumns)+1)\n\n  if column != pkColumns[0] {\n    orderBy = append(orderBy, fmt.Sprintf(\"%s %s NULLS LAST\", column, order))\n  }\n  for _, pkColumn := range pkColumns {\n    orderBy = append(orderBy, fmt.Sprintf(\"%s %s\", pkColumn, order))\n  }\n  return orderBy\n}\n\n// keysetWhere returns condition for the rows after the cursor row in keysetOrderBy order\nfunc keysetWhere(column string, order sortOrder, value any, valueNull bool, pkColumns []string, key []any) sq.Sqlizer {\n  operator := \">\"\n\n  if order == SortOrderDesc {\n    operator = \"<\"\n  }\n  // Primary key columns compared as a row\n  afterKey := sq.Expr(fmt.Sprintf(\"%s %s %s\", rowColumns(pkColumns), operator, rowPlaceholders(len(pkColumns))), key...)\n\n  if column == pkColumns[0] {\n    return afterKey\n  }\n  isNull := sq.Expr(fmt.Sprintf(\"%s IS NULL\", column))\n\n  if valueNull {\n    return sq.And{isNull, afterKey}\n  }\n  return sq.Or{\n    sq.Expr(fmt.Sprintf(\"%s %s ?\", column, operator), value),\n    sq.And{\n      sq.Expr(fmt.Sprintf(\"%s = ?\", column), value),\n      afterKey,\n    },\n    isNull,\n  }\n}\n\n// keyWhere returns condition for the row with the primary key values\nfunc keyWhere(pkColumns []string, key []any) sq.Sqlizer {\n  where := make(sq.And, 0, len(pkColumns))\n\n  for idx, pkColumn := range pkColumns {\n    where = append(where, sq.Expr(pkColumn+\" = ?\", key[idx]))\n  }\n  return where\n}\n\n// keysWhere returns condition for the rows with one of the primary key values\nfunc keysWhere(pkColumns []string, keys [][]any) sq.Sqlizer {\n  rows := make([]string, 0, len(keys))\n  args := make([]any, 0, len(keys)*len(pkColumns))\n\n  for _, key := range keys {\n    rows = append(rows, rowPlaceholders(len(pkColumns)))\n    args = append(args, key...)\n  }\n  return sq.Expr(fmt.Sprintf(\"%s IN (%s)\", rowColumns(pkColumns), strings.Join(rows, \", \")), args...)\n}\n\nfunc rowColumns(columns []string) string {\n  if len(columns) == 1 {\n    return columns[0]\n  }\n  return \"(\" + strings.Join(columns, \", \") + \")\"\n}\n\nfunc rowPlaceholders(count int) string {\n  if count == 1 {\n    return \"?\"\n  }\n  return \"(\" + strings.TrimSuffix(strings.Repeat(\"?, \", count), \", \") + \")\"\n}\n\n// TimeRange of the time filter, From is inclusive and To is exclusive,\n// null bounds are not checked\ntype TimeRange struct {\n  From zero.Time\n  To   zero.Time\n}\n\nfunc timeRangeWhere(column string, r *TimeRange) sq.Sqlizer {\n  where := sq.And{}\n\n  if r.From.Valid {\n    where = append(where, sq.GtOrEq{column: r.From.Time})\n  }\n  if r.To.Valid {\n    where = append(where, sq.Lt{column: r.To.Time})\n  }\n  return where\n}\n\n// isNullWhere returns IS NULL condition for true value and IS NOT NULL for false value\nfunc isNullWhere(column string, null bool) sq.Sqlizer {\n  if null {\n    return sq.Eq{column: nil}\n  }\n  return sq.NotEq{column: nil}\n}\n\n// Pattern conditions with wildcards of the value escaped\nfunc startsWithWhere(column, value string) sq.Sqlizer {\n  return sq.Like{column: escapeLike(value) + \"%\"}\n}\n\nfunc iStartsWithWhere(column, value string) sq.Sqlizer {\n  return sq.ILike{column: escapeLike(value) + \"%\"}\n}\n\nfunc containsWhere(column, value string) sq.Sqlizer {\n  return sq.Like{column: \"%\" + escapeLike(value) + \"%\"}\n}\n\nfunc iContainsWhere(column, value string) sq.Sqlizer {\n  return sq.ILike{column: \"%\" + escapeLike(value) + \"%\"}\n}\n\nfunc escapeLike(value string) string {\n  return likeEscaper.Replace(value)\n}\n\nvar likeEscaper = strings.NewReplacer(`\\`, `\\\\`, `%`, `\\%`, `_`, `\\_`)\n\n// arrayContainsWhere returns condition for the array column containing all values\nfunc arrayContainsWhere(column string, values any) sq.Sqlizer {\n  return sq.Expr(column+\" @> ?\", values)\n}\n\n// arrayOverlapsWhere returns condition for the array column containing any of values\nfunc arrayOverlapsWhere(column string, values any) sq.Sqlizer {\n  return sq.Expr(column+\" && ?\", values)\n}\n\n// Aggregate functions of the numeric columns\nconst (\n  aggregateSum = \"sum\"\n  aggregateMin = \"min\"\n  aggregateMax = \"max\"\n)\n\nvar aggregateFunctions = []string{aggregateSum, aggregateMin, aggregateMax}\n\n// aggregateColumns returns results of the function on the fields aliased as field_function,\n// fields are checked with the aggregated fields of the model\nfunc aggregateColumns[F ~string](function string, fields, aggregated []F) ([]string, error) {\n  columns := make([]string, 0, len(fields))\n\n  for _, field := range fields {\n    if !containsField(aggregated, field) {\n      return nil, fmt.Errorf(\"field %s cannot be aggregated with %s\", field, function)\n    }\n    columns = append(columns, fmt.Sprintf(\"%s(%s) AS %s_%s\", function, field, field, function))\n  }\n  return columns, nil\n}\n\nfunc containsField[F ~string](fields []F, field F) bool {\n  for _, other := range fields {\n    if other == field {\n      return true\n    }\n  }\n  return false\n}\n"
+  // StorageQuery ...
+  StorageQuery = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\nimport (\n  {{- range .QueryPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// ReadOnlyColumn of the model M with values of the type T, conditions\n// of the column are checked with model and value types\ntype ReadOnlyColumn[M, T any] struct {\n  name string\n}\n\nfunc newReadOnlyColumn[M, T any](name string) ReadOnlyColumn[M, T] {\n  return ReadOnlyColumn[M, T]{\n    name: name,\n  }\n}\n\nfunc (c ReadOnlyColumn[M, T]) Name() string {\n  return c.name\n}\n\nfunc (c ReadOnlyColumn[M, T]) Eq(value T) Condition[M] {\n  return Condition[M]{sq.Eq{c.name: value}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) NotEq(value T) Condition[M] {\n  return Condition[M]{sq.NotEq{c.name: value}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) Gt(value T) Condition[M] {\n  return Condition[M]{sq.Gt{c.name: value}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) GtOrEq(value T) Condition[M] {\n  return Condition[M]{sq.GtOrEq{c.name: value}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) Lt(value T) Condition[M] {\n  return Condition[M]{sq.Lt{c.name: value}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) LtOrEq(value T) Condition[M] {\n  return Condition[M]{sq.LtOrEq{c.name: value}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) In(values ...T) Condition[M] {\n  return Condition[M]{sq.Eq{c.name: values}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) NotIn(values ...T) Condition[M] {\n  return Condition[M]{sq.NotEq{c.name: values}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) IsNull() Condition[M] {\n  return Condition[M]{sq.Eq{c.name: nil}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) IsNotNull() Condition[M] {\n  return Condition[M]{sq.NotEq{c.name: nil}}\n}\n\nfunc (c ReadOnlyColumn[M, T]) Asc() Order[M] {\n  return Order[M]{fmt.Sprintf(\"%s %s\", c.name, SortOrderAsc)}\n}\n\nfunc (c ReadOnlyColumn[M, T]) Desc() Order[M] {\n  return Order[M]{fmt.Sprintf(\"%s %s\", c.name, SortOrderDesc)}\n}\n\n// Column of the model M assigned by update queries\ntype Column[M, T any] struct {\n  ReadOnlyColumn[M, T]\n}\n\nfunc newColumn[M, T any](name string) Column[M, T] {\n  return Column[M, T]{newReadOnlyColumn[M, T](name)}\n}\n\nfunc (c Column[M, T]) Set(value T) Assignment[M] {\n  return Assignment[M]{column: c.name, value: value}\n}\n\nfunc (c Column[M, T]) SetNull() Assignment[M] {\n  return Assignment[M]{column: c.name, value: nil}\n}\n\n// ReadOnlyStringColumn of the model M with pattern conditions\ntype ReadOnlyStringColumn[M any] struct {\n  ReadOnlyColumn[M, string]\n}\n\nfunc newReadOnlyStringColumn[M any](name string) ReadOnlyStringColumn[M] {\n  return ReadOnlyStringColumn[M]{newReadOnlyColumn[M, string](name)}\n}\n\nfunc (c ReadOnlyStringColumn[M]) Like(pattern string) Condition[M] {\n  return Condition[M]{sq.Like{c.name: pattern}}\n}\n\nfunc (c ReadOnlyStringColumn[M]) NotLike(pattern string) Condition[M] {\n  return Condition[M]{sq.NotLike{c.name: pattern}}\n}\n\nfunc (c ReadOnlyStringColumn[M]) ILike(pattern string) Condition[M] {\n  return Condition[M]{sq.ILike{c.name: pattern}}\n}\n\nfunc (c ReadOnlyStringColumn[M]) NotILike(pattern string) Condition[M] {\n  return Condition[M]{sq.NotILike{c.name: pattern}}\n}\n\n// StringColumn of the model M with pattern conditions assigned by update queries\ntype StringColumn[M any] struct {\n  ReadOnlyStringColumn[M]\n}\n\nfunc newStringColumn[M any](name string) StringColumn[M] {\n  return StringColumn[M]{newReadOnlyStringColumn[M](name)}\n}\n\nfunc (c StringColumn[M]) Set(value string) Assignment[M] {\n  return Assignment[M]{column: c.name, value: value}\n}\n\nfunc (c StringColumn[M]) SetNull() Assignment[M] {\n  return Assignment[M]{column: c.name, value: nil}\n}\n\n// ReadOnlyArrayColumn of the model M with elements of the type E\ntype ReadOnlyArrayColumn[M, E any] struct {\n  ReadOnlyColumn[M, []E]\n}\n\nfunc newReadOnlyArrayColumn[M, E any](name string) ReadOnlyArrayColumn[M, E] {\n  return ReadOnlyArrayColumn[M, E]{newReadOnlyColumn[M, []E](name)}\n}\n\n// Contains returns condition for the array containing all values\nfunc (c ReadOnlyArrayColumn[M, E]) Contains(values ...E) Condition[M] {\n  return Condition[M]{arrayContainsWhere(c.name, values)}\n}\n\n// Overlaps returns condition for the array containing any of values\nfunc (c ReadOnlyArrayColumn[M, E]) Overlaps(values ...E) Condition[M] {\n  return Condition[M]{arrayOverlapsWhere(c.name, values)}\n}\n\n// ArrayColumn of the model M with elements of the type E assigned by update queries\ntype ArrayColumn[M, E any] struct {\n  ReadOnlyArrayColumn[M, E]\n}\n\nfunc newArrayColumn[M, E any](name string) ArrayColumn[M, E] {\n  return ArrayColumn[M, E]{newReadOnlyArrayColumn[M, E](name)}\n}\n\nfunc (c ArrayColumn[M, E]) Set(values []E) Assignment[M] {\n  return Assignment[M]{column: c.name, value: values}\n}\n\nfunc (c ArrayColumn[M, E]) SetNull() Assignment[M] {\n  return Assignment[M]{column: c.name, value: nil}\n}\n\n// Condition on the columns of the model M\ntype Condition[M any] struct {\n  sqlizer sq.Sqlizer\n}\n\nfunc (c Condition[M]) ToSql() (string, []any, error) {\n  return c.sqlizer.ToSql()\n}\n\n// And returns condition matching all conditions\nfunc And[M any](conditions ...Condition[M]) Condition[M] {\n  return Condition[M]{toSqlizers[M, sq.And](conditions)}\n}\n\n// Or returns condition matching any of conditions\nfunc Or[M any](conditions ...Condition[M]) Condition[M] {\n  return Condition[M]{toSqlizers[M, sq.Or](conditions)}\n}\n\n// Not returns condition matching models not matched by the condition\nfunc Not[M any](condition Condition[M]) Condition[M] {\n  return Condition[M]{sq.Expr(\"NOT (?)\", condition.sqlizer)}\n}\n\nfunc toSqlizers[M any, S sq.And | sq.Or](conditions []Condition[M]) S {\n  sqlizers := make(S, 0, len(conditions))\n\n  for _, condition := range conditions {\n    sqlizers = append(sqlizers, condition.sqlizer)\n  }\n  return sqlizers\n}\n\n// Order by the column of the model M\ntype Order[M any] struct {\n  clause string\n}\n\n// Assignment of the column value of the model M\ntype Assignment[M any] struct {\n  column string\n  value  any\n}\n\n// SelectQuery of the models M executed with executor\ntype SelectQuery[M any] struct {\n  executor pg.Executor\n  builder  sq.SelectBuilder\n}\n\nfunc (q *SelectQuery[M]) Where(conditions ...Condition[M]) *SelectQuery[M] {\n  for _, condition := range conditions {\n    q.builder = q.builder.Where(condition.sqlizer)\n  }\n  return q\n}\n\nfunc (q *SelectQuery[M]) OrderBy(orders ...Order[M]) *SelectQuery[M] {\n  for _, order := range orders {\n    q.builder = q.builder.OrderBy(order.clause)\n  }\n  return q\n}\n\nfunc (q *SelectQuery[M]) Limit(limit uint64) *SelectQuery[M] {\n  q.builder = q.builder.Limit(limit)\n  return q\n}\n\nfunc (q *SelectQuery[M]) Offset(offset uint64) *SelectQuery[M] {\n  q.builder = q.builder.Offset(offset)\n  return q\n}\n\nfunc (q *SelectQuery[M]) ToSql() (string, []any, error) {\n  return q.builder.ToSql()\n}\n\nfunc (q *SelectQuery[M]) List(ctx context.Context) ([]*M, error) {\n  return pg.SelectCtx[*M](ctx, q.executor, q.builder)\n}\n\nfunc (q *SelectQuery[M]) Get(ctx context.Context) (*M, error) {\n  return pg.GetCtx[*M](ctx, q.executor, q.builder.Limit(1))\n}\n\n// UpdateQuery of the models M returning updated models,\n// query without conditions is not executed\ntype UpdateQuery[M any] struct {\n  executor pg.Executor\n  builder  sq.UpdateBuilder\n  where    bool\n}\n\nfunc (q *UpdateQuery[M]) Set(assignments ...Assignment[M]) *UpdateQuery[M] {\n  for _, assignment := range assignments {\n    q.builder = q.builder.Set(assignment.column, assignment.value)\n  }\n  return q\n}\n\nfunc (q *UpdateQuery[M]) Where(conditions ...Condition[M]) *UpdateQuery[M] {\n  for _, condition := range conditions {\n    q.builder = q.builder.Where(condition.sqlizer)\n    q.where = true\n  }\n  return q\n}\n\nfunc (q *UpdateQuery[M]) ToSql() (string, []any, error) {\n  return q.builder.ToSql()\n}\n\nfunc (q *UpdateQuery[M]) Exec(ctx context.Context) ([]*M, error) {\n  if !q.where {\n    return nil, fmt.Errorf(\"update query without conditions\")\n  }\n  return pg.SelectCtx[*M](ctx, q.executor, q.builder)\n}\n\n// DeleteQuery of the models M returning deleted models,\n// query without conditions is not executed\ntype DeleteQuery[M any] struct {\n  executor pg.Executor\n  builder  sq.DeleteBuilder\n  where    bool\n}\n\nfunc (q *DeleteQuery[M]) Where(conditions ...Condition[M]) *DeleteQuery[M] {\n  for _, condition := range conditions {\n    q.builder = q.builder.Where(condition.sqlizer)\n    q.where = true\n  }\n  return q\n}\n\nfunc (q *DeleteQuery[M]) ToSql() (string, []any, error) {\n  return q.builder.ToSql()\n}\n\nfunc (q *DeleteQuery[M]) Exec(ctx context.Context) ([]*M, error) {\n  if !q.where {\n    return nil, fmt.Errorf(\"delete query without conditions\")\n  }\n  return pg.SelectCtx[*M](ctx, q.executor, q.builder)\n}\n"
   // StorageStorage ...
   StorageStorage = "// Code generated by Boiler; DO NOT EDIT.\n\npackage storage\n\nimport (\n  {{- range .StoragePackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\ntype Storage struct {\n  executor pg.Executor\n}\n\nfunc NewStorage(executor pg.Executor) *Storage {\n  return &Storage{\n    executor: executor,\n  }\n}\n\nfunc (s *Storage) WithTransaction(ctx context.Context, fTx func(*Storage) error) error {\n  defer func() {\n    if rec := recover(); rec != nil {\n      log.Errorf(\"client.WithTransaction: panic recovered: %v\", rec)\n    }\n  }()\n\n  tx, err := s.executor.Begin(ctx)\n  if err != nil {\n    return fmt.Errorf(\"s.executor.BeginTx: %w\", err)\n  }\n  txStorage := NewStorage(tx)\n\n  if err = fTx(txStorage); err != nil {\n    if errTx := tx.Rollback(ctx); errTx != nil {\n      log.Errorf(\"Storage.WithTransaction: tx.Rollback: %v\", errTx)\n    }\n    return err\n  }\n\n  if txErr := tx.Commit(ctx); txErr != nil {\n    return fmt.Errorf(\"tx.Commit: %w\", err)\n  }\n  return nil\n}\n\n"
 
   // StorageConfig ...
-  StorageConfig = "# Config generated by Boiler; YOU MUST CHANGE THIS.\n\n# Boiler storage generator config\nstorage_config:\n  # 1. Fill one of config sections\n\n  # 1.1. Connection config\n  pg_config:\n    host: \"\"\n    port: \"\"\n    user: \"\"\n    db_name: \"\"\n    password: \"\"\n\n  # 1.2. Path to pg dump file\n  #  pg_dump_path: \"\"\n\n  # 2. Fill pg table section optionally\n\n  # 2.1. Config for pg tables\n  pg_table_config:\n\n    # Filters for each table by default\n    pg_column_filter:\n      # Generate all filters / Skip all filters\n      all_by_default: true # false\n\n      string: [ \"In\", \"NotIn\", \"Like\", \"NotLike\" ]\n      numeric: [ \"Lt\", \"LtOrEq\", \"Gt\", \"GtOrEq\", \"In\", \"NotIn\" ]\n      # Bool always has one filter \"Where\"\n      # Time is the same as numeric\n\n      # Fill overrides for specific tables\n      overrides:\n\n        # Example for \"dummy\" table\n        dummy:\n          id: [ \"In\", \"NotIn\" ]\n          string: [ \"In\", \"Like\" ]\n          enum: [ \"In\", \"NotIn\" ] # Enum must be numeric column\n          # Bool always has one filter \"Where\"\n          numeric: [ \"Lt\", \"LtOrEq\", \"Gt\", \"GtOrEq\", \"In\", \"NotIn\" ]\n          time: [ \"GtOrEq\", \"LtOrEq\" ] # Time is the same as numeric\n\n    # Skip generation for tables matching names\n    pg_skip_tables: [ \"dummy_outbox\" ]\n\n    # Skip generation for custom storages matching names\n    pg_skip_custom_storages: [ \"rocket_lock\" ]\n\n  # 3. Fill pg column types section optionally\n\n  # 3.1. Config for pg column types\n  pg_type_config:\n\n    # Example for \"citext\" column type\n    citext:\n      go_type: \"string\"\n      go_zero_type: \"zero.String\"\n"
+  StorageConfig = "# Config generated by Boiler; YOU MUST CHANGE THIS.\n\n# Boiler storage generator config\nstorage_config:\n  # 1. Fill one of config sections\n\n  # 1.1. Connection config\n  pg_config:\n    host: \"\"\n    port: \"\"\n    user: \"\"\n    db_name: \"\"\n    password: \"\"\n\n  # 1.2. Path to pg dump file\n  #  pg_dump_path: \"\"\n\n  # 2. Fill pg table section optionally\n\n  # 2.1. Config for pg tables\n  pg_table_config:\n\n    # Filters for each table by default\n    pg_column_filter:\n      # Generate all filters / Skip all filters\n      all_by_default: true # false\n\n      string: [ \"In\", \"NotIn\", \"Like\", \"NotLike\", \"ILike\", \"NotILike\", \"StartsWith\", \"IStartsWith\", \"Contains\", \"IContains\", \"IsNull\" ]\n      numeric: [ \"Lt\", \"LtOrEq\", \"Gt\", \"GtOrEq\", \"In\", \"NotIn\", \"IsNull\" ]\n      array: [ \"Contains\", \"Overlaps\", \"IsNull\" ]\n      # Bool always has one filter \"Where\"\n      # Time is the same as numeric with \"Range\" filter\n      # \"IsNull\" is generated for nullable columns only\n\n      # Fill overrides for specific tables\n      overrides:\n\n        # Example for \"dummy\" table\n        dummy:\n          id: [ \"In\", \"NotIn\" ]\n          string: [ \"In\", \"Like\" ]\n          enum: [ \"In\", \"NotIn\" ] # Enum must be numeric column\n          # Bool always has one filter \"Where\"\n          numeric: [ \"Lt\", \"LtOrEq\", \"Gt\", \"GtOrEq\", \"In\", \"NotIn\" ]\n          time: [ \"GtOrEq\", \"LtOrEq\" ] # Time is the same as numeric\n\n    # Skip generation for tables matching names\n    pg_skip_tables: [ \"dummy_outbox\" ]\n\n    # Skip generation for custom storages matching names\n    pg_skip_custom_storages: [ \"rocket_lock\" ]\n\n    # Upserts for specific tables, upserts on primary key\n    # and each unique key are generated by default\n    pg_upserts:\n\n      # Example for \"dummy\" table\n      dummy:\n        # Conflict columns must match primary key or unique key\n        - conflict_columns: [ \"email\" ]\n          # All insert columns except conflict and primary key by default\n          update_columns: [ \"name\" ]\n\n    # Overrides of the generated models for specific tables\n    pg_tables:\n\n      # Example for \"dummy\" table\n      dummy:\n        model_name: \"Dummy\"\n        # Methods: \"Get\", \"List\", \"Create\", \"Update\", \"Delete\", \"Upsert\"\n        skip_methods: [ \"Delete\" ]\n        columns:\n          uid:\n            field_name: \"UID\"\n          # Column managed by database is not set with inputs\n          updated_at:\n            skip_input: true\n          # Read only column is set on create only\n          created_by:\n            read_only: true\n\n  # 3. Fill pg column types section optionally\n\n  # 3.1. Config for pg column types\n  # Enum types from the dump generated as Go enums if not overridden here\n  pg_type_config:\n\n    # Example for \"citext\" column type\n    citext:\n      go_type: \"string\"\n      go_zero_type: \"zero.String\"\n\n  # 3.2. Config for specific pg table columns, overrides pg type config\n  # Jsonb column mapped to the struct is marshalled automatically\n  pg_column_type_config:\n\n    # Example for \"dummy\" table\n    dummy:\n      payload:\n        go_type: \"dto.Payload\"\n        go_zero_type: \"*dto.Payload\"\n        go_import: \"github.com/dummy/internal/app/dto\"\n"
 
   // StorageCustomModel ...
   StorageCustomModel = "// Code generated by Boiler; DO NOT EDIT.\n\npackage models\n\nimport (\n  {{- range .ModelPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n{{.StructDescription}}\n"
@@ -190,11 +218,17 @@ const (
 // Config Generator compiled templates
 const (
   // GenConfigEmpty const for compiled Boiler build with empty config for generation
-  GenConfigEmpty = "# Config generated by Boiler; YOU MUST CHANGE THIS.\nversion: \"1\"\n\n# Boiler app section\napp:\n  name: \"app\"\n  version: \"v0.0.1\"\n  description: \"test app description\"\n\n# Custom keys section\ncustom:\n  first_group_key:\n    group: \"first_group\"\n    type: \"int\"\n    value: \"1\"\n    description: \"test first group key\"\n\n  second_group_key:\n    group: \"second_group\"\n    type: \"duration\"\n    value: \"10s\"\n    description: \"test second group key\"\n"
+  GenConfigEmpty = "# Config generated by Boiler; YOU MUST CHANGE THIS.\nversion: \"1\"\n\n# Boiler app section\napp:\n  name: \"app\"\n  version: \"v0.0.1\"\n  description: \"test app description\"\n\n# Custom keys section\ncustom:\n  first_group_key:\n    group: \"first_group\"\n    type: \"int\"\n    value: \"1\"\n    description: \"test first group key\"\n    # Optional validation rules: min, max, pattern, one_of\n    rules:\n      min: \"1\"\n      max: \"10\"\n\n  second_group_key:\n    group: \"second_group\"\n    type: \"duration\"\n    value: \"10s\"\n    description: \"test second group key\"\n\n  # Log options applied live without restart, also available:\n  # log_format (enum: json, logfmt, text), log_sampling (json: tick, first, thereafter),\n  # log_package_levels (map[string]string: package path to level)\n  # Json keys are unmarshalled into go_type with import path if specified:\n  #   go_type: \"github.com/acme/app/internal/dto.Limits\"\n  log_level:\n    group: \"log\"\n    type: \"enum\"\n    value: \"info\"\n    description: \"log level\"\n    rules:\n      one_of: [\"panic\", \"fatal\", \"error\", \"warn\", \"info\", \"debug\", \"trace\"]\n"
   // GenConfigConfig const for compiled Boiler build with generated config
-  GenConfigConfig = "// Code generated by Boiler; DO NOT EDIT.\n\npackage config\n\nimport (\n  {{- range .ConfigPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n{{- range $cg := .ConfigGroups}}\nconst (\n  {{- range .GroupKeys}}\n  // {{.KeyComment}}\n  {{toUpperCamelCase .KeyName}} configKey = \"{{toSnakeCase .KeyName}}\"\n  {{- end}}\n)\n{{end}}\n\n// configKey strict type for config key\ntype configKey string\n\n// Get value of the specified key\nfunc Get(ctx context.Context, key configKey) types.Value {\n  return config.ContextClient(ctx).GetValue(ctx, string(key))\n}\n\n// Watch watches changes to the value of the specified key\nfunc Watch(ctx context.Context, key configKey, action func(value types.Value)) {\n  config.ContextClient(ctx).WatchValue(ctx, string(key), action)\n}\n"
+  GenConfigConfig = "// Code generated by Boiler; DO NOT EDIT.\n\npackage config\n\nimport (\n  {{- range .ConfigPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n{{- range $cg := .ConfigGroups}}\nconst (\n  {{- range .GroupKeys}}\n  // {{.KeyComment}}\n  {{toUpperCamelCase .KeyName}}Key configKey = \"{{toSnakeCase .KeyName}}\"\n  {{- end}}\n)\n{{end}}\n\n// configKey strict type for config key\ntype configKey string\n\n// Get value of the specified key\nfunc Get(ctx context.Context, key configKey) types.Value {\n  return config.ContextClient(ctx).GetValue(ctx, string(key))\n}\n\n// Watch delivers the current value of the specified key and then its changes\nfunc Watch(ctx context.Context, key configKey, action func(value types.Value)) {\n  config.ContextClient(ctx).WatchValue(ctx, string(key), action)\n}\n"
   // GenConfigProvider ...
   GenConfigProvider = "// Code generated by Boiler; DO NOT EDIT.\n\npackage config\n\nimport (\n  {{- range .ProviderPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Provider config value provider\ntype Provider struct {\n  key   configKey\n  value atomic.Value\n}\n\n// NewProvider create new Provider for specified key\nfunc NewProvider(ctx context.Context, key configKey) *Provider {\n  p := new(Provider)\n  p.key = key\n  value := Get(ctx, key)\n  p.value.Store(value)\n  return p\n}\n\n// Watch watches changes to the value of the specified key\nfunc (p *Provider) Watch(ctx context.Context) *Provider {\n  Watch(ctx, p.key, func(value types.Value) {\n    p.value.Store(value)\n  })\n  return p\n}\n\n// Provide value of the specified key\nfunc (p *Provider) Provide() types.Value {\n  if value := p.value.Load(); value != nil {\n    return value.(types.Value)\n  }\n  return types.NewNilValue()\n}\n"
+  // GenConfigAccessors const for compiled Boiler build with generated typed config accessors
+  GenConfigAccessors = "// Code generated by Boiler; DO NOT EDIT.\n\npackage config\n\nimport (\n  {{- range .AccessorsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n// Default values of the keys from the config file\nvar (\n  {{- range .AccessorKeys}}\n  {{- if .HasDefault}}\n  {{toLowerCamelCase .KeyName}}Default = defaultValue(\"{{.Type}}\", {{printf \"%q\" .DefaultValue}})\n  {{- else}}\n  {{toLowerCamelCase .KeyName}}Default = types.NewNilValue()\n  {{- end}}\n  {{- end}}\n)\n{{- range .AccessorKeys}}\n{{- if .GoType}}\n\n// {{toUpperCamelCase .KeyName}} {{.KeyComment}}\nfunc {{toUpperCamelCase .KeyName}}(ctx context.Context) ({{.GoType}}, error) {\n  return {{toLowerCamelCase .KeyName}}Value(valueOrDefault(ctx, {{toUpperCamelCase .KeyName}}Key, {{toLowerCamelCase .KeyName}}Default))\n}\n\n// Watch{{toUpperCamelCase .KeyName}} delivers the latest values of {{toUpperCamelCase .KeyName}} until ctx done,\n// values failed to unmarshal are skipped\nfunc Watch{{toUpperCamelCase .KeyName}}(ctx context.Context) <-chan {{.GoType}} {\n  return watchJSONValue(ctx, {{toUpperCamelCase .KeyName}}Key, {{toLowerCamelCase .KeyName}}Default, {{toLowerCamelCase .KeyName}}Value)\n}\n\nfunc {{toLowerCamelCase .KeyName}}Value(value types.Value) ({{.GoType}}, error) {\n  var typed {{.GoType}}\n  err := value.JSON(&typed)\n  return typed, err\n}\n{{- else}}\n\n// {{toUpperCamelCase .KeyName}} {{.KeyComment}}\n{{- if .IsJSON}}\n// Raw json is unmarshalled with types.Value JSON method or go_type of the key\n{{- end}}\nfunc {{toUpperCamelCase .KeyName}}(ctx context.Context) {{.ValueType}} {\n  return {{toLowerCamelCase .KeyName}}Value(valueOrDefault(ctx, {{toUpperCamelCase .KeyName}}Key, {{toLowerCamelCase .KeyName}}Default))\n}\n\n// Watch{{toUpperCamelCase .KeyName}} delivers the latest values of {{toUpperCamelCase .KeyName}} until ctx done\nfunc Watch{{toUpperCamelCase .KeyName}}(ctx context.Context) <-chan {{.ValueType}} {\n  return watchValue(ctx, {{toUpperCamelCase .KeyName}}Key, {{toLowerCamelCase .KeyName}}Default, {{toLowerCamelCase .KeyName}}Value)\n}\n\nfunc {{toLowerCamelCase .KeyName}}Value(value types.Value) {{.ValueType}} {\n  {{- if .IsJSON}}\n  raw, _ := value.Any().(json.RawMessage)\n  return raw\n  {{- else}}\n  return value.{{.ValueCall}}()\n  {{- end}}\n}\n{{- end}}\n{{- end}}\n\n// defaultValue casts default value of the key from the config file\nfunc defaultValue(typ, rawValue string) types.Value {\n  value, err := types.CastValue(typ, rawValue)\n  if err != nil {\n    return types.NewNilValue()\n  }\n  return value\n}\n\n// valueOrDefault returns value of the key or default value if key not set\nfunc valueOrDefault(ctx context.Context, key configKey, def types.Value) types.Value {\n  if value := Get(ctx, key); !value.IsNil() {\n    return value\n  }\n  return def\n}\n\n// watchValue delivers the latest casted values of the key, stale values are dropped\nfunc watchValue[T any](ctx context.Context, key configKey, def types.Value, cast func(types.Value) T) <-chan T {\n  ch := make(chan T, 1)\n\n  Watch(ctx, key, func(value types.Value) {\n    if value.IsNil() {\n      value = def\n    }\n    sendLatest(ch, cast(value))\n  })\n  return ch\n}\n\n// watchJSONValue delivers the latest unmarshalled values of the key, values failed to unmarshal are skipped\nfunc watchJSONValue[T any](ctx context.Context, key configKey, def types.Value, unmarshal func(types.Value) (T, error)) <-chan T {\n  ch := make(chan T, 1)\n\n  Watch(ctx, key, func(value types.Value) {\n    if value.IsNil() {\n      value = def\n    }\n    if next, err := unmarshal(value); err == nil {\n      sendLatest(ch, next)\n    }\n  })\n  return ch\n}\n\n// sendLatest sends value to the channel with buffer of one value, stale value is dropped\nfunc sendLatest[T any](ch chan T, next T) {\n  for {\n    select {\n    case ch <- next:\n      return\n    default:\n    }\n    // Drop stale value\n    select {\n    case <-ch:\n    default:\n    }\n  }\n}\n"
+  // GenConfigDocs const for compiled Boiler build with generated config reference
+  GenConfigDocs = "<!-- Code generated by Boiler; DO NOT EDIT. -->\n\n# {{.AppName}} config\n\n{{.AppDescription}}\n{{- range .ConfigGroups}}\n\n## {{.GroupName}}\n\n| Key | Type | Default | Rules | Description |\n|-----|------|---------|-------|-------------|\n{{- range .GroupKeys}}\n| `{{.KeyName}}` | `{{.Type}}` | {{if .HasDefault}}`{{toMarkdownCell .DefaultValue}}`{{else}}-{{end}} | {{if .RulesDesc}}{{toMarkdownCell .RulesDesc}}{{else}}-{{end}} | {{toMarkdownCell .KeyComment}} |\n{{- end}}\n{{- end}}\n"
+  // GenConfigFlags const for compiled Boiler build with generated feature flags accessors
+  GenConfigFlags = "// Code generated by Boiler; DO NOT EDIT.\n\npackage config\n\nimport (\n  {{- range .FlagsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\n{{- range .FlagKeys}}\n\n// {{toUpperCamelCase .KeyName}}Enabled {{.KeyComment}}\nfunc {{toUpperCamelCase .KeyName}}Enabled(ctx context.Context) bool {\n  return flags.Enabled(ctx, string({{toUpperCamelCase .KeyName}}Key))\n}\n\n// {{toUpperCamelCase .KeyName}}Variant {{.KeyComment}}\nfunc {{toUpperCamelCase .KeyName}}Variant(ctx context.Context) string {\n  return flags.Variant(ctx, string({{toUpperCamelCase .KeyName}}Key))\n}\n{{- end}}\n"
 
   // GenConfigGroups const for compiled Boiler build with generated config groups;
   // Deprecated; DO NOT USE
@@ -204,9 +238,9 @@ const (
 // Kafka Outbox Generator compiled templates
 const (
   // KafkaOutbox ...
-  KafkaOutbox = "// Code generated by Boiler; DO NOT EDIT.\npackage kafkaoutbox\n\nimport (\n  {{- range .OutboxPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\ntype Outbox struct {\n  config   Config\n  storage  Storage\n  producer Producer\n}\n\ntype Producer interface {\n  SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error)\n}\n\ntype Storage interface {\n  LockRecords(ctx context.Context, tableName string) ([]*Record, error)\n  DeleteRecord(ctx context.Context, tableName, recordID string) error\n}\n\nfunc New(config Config, storage Storage, producer Producer) *Outbox {\n  return &Outbox{\n    config:   config,\n    storage:  storage,\n    producer: producer,\n  }\n}\n\nfunc (o *Outbox) Run(ctx context.Context) error {\n  if err := o.Validate(); err != nil {\n    return fmt.Errorf(\"outbox validation failed: %w\", err)\n  }\n  for _, tableName := range tableNames {\n    runJitter(o.config.JitterFactor)\n    o.sendRecordsWithWorkers(ctx, tableName)\n\n    log.Infof(\"kafka-outbox: sending started for: %s table\", tableName)\n  }\n  log.Infof(\"kafka-outbox: sending in progress\")\n\n  return nil\n}\n\nfunc (o *Outbox) Validate() error {\n  return validation.ValidateStruct(o,\n    validation.Field(&o.config),\n    validation.Field(&o.storage, validation.Required),\n    validation.Field(&o.producer, validation.Required),\n  )\n}\n\nfunc (o *Outbox) sendRecordsWithWorkers(ctx context.Context, tableName string) {\n  for worker := 0; worker < int(o.config.WorkersCount); worker++ {\n    runJitter(o.config.JitterFactor)\n    go func() { o.sendRecordsWithIdle(ctx, tableName) }()\n  }\n}\n\nfunc (o *Outbox) sendRecordsWithIdle(ctx context.Context, tableName string) {\n  ticker := time.NewTicker(o.config.WorkerIdle)\n  for {\n    select {\n    case <-ticker.C:\n      if err := o.sendRecords(ctx, tableName); err != nil {\n        log.Errorf(\"outbox.send: table: %s: error: %v\", tableName, err)\n      }\n    case <-ctx.Done():\n      log.Errorf(\"outbox.send: table: %s: context cancelled\", tableName)\n      return\n    }\n  }\n}\n\nfunc (o *Outbox) sendRecords(ctx context.Context, tableName string) error {\n  records, err := o.storage.LockRecords(ctx, tableName)\n  if err != nil {\n    return fmt.Errorf(\"storage.BatchRecords: %w\", err)\n  }\n  var msgBuf []byte\n\n  for _, record := range records {\n    msgBuf, err = marshalRecord(tableName, record)\n    if err != nil {\n      return fmt.Errorf(\"marshalRecord: %w\", err)\n    }\n    topicName, ok := tableTopics[tableName]\n    if !ok {\n      return fmt.Errorf(\"topic not found: %s table\", tableName)\n    }\n    msgKey := record.ID\n\n    msgHeaders := []sarama.RecordHeader{\n      {\n        Key:   []byte(\"action_typ\"),\n        Value: []byte(record.ActionTyp.String()),\n      },\n    }\n    _, _, err = o.producer.SendMessage(&sarama.ProducerMessage{\n      Topic:     topicName,\n      Key:       sarama.StringEncoder(msgKey),\n      Value:     sarama.StringEncoder(msgBuf),\n      Headers:   msgHeaders,\n      Timestamp: time.Now().UTC(),\n    })\n    if err != nil {\n      return fmt.Errorf(\"producer.SendMessage: %w\", err)\n    }\n    if err = o.storage.DeleteRecord(ctx, tableName, record.ID); err != nil {\n      return fmt.Errorf(\"storage.DeleteRecord: %w\", err)\n    }\n  }\n  return nil\n}\n\nfunc marshalRecord(tableName string, record *Record) ([]byte, error) {\n  typ, ok := tableTypes[tableName]\n  if !ok {\n    return nil, fmt.Errorf(\"type not found: %s table\", tableName)\n  }\n  refTyp := reflect.TypeOf(typ)\n  pb := reflect.New(refTyp).Interface().(protoreflect.ProtoMessage)\n\n  pbOpts := protojson.UnmarshalOptions{\n    AllowPartial:   true,\n    DiscardUnknown: true,\n  }\n  if err := pbOpts.Unmarshal(record.JSONOut, pb); err != nil {\n    return nil, fmt.Errorf(\"protojson.Unmarshal: %s table: %w\", tableName, err)\n  }\n  buf, err := protojson.Marshal(pb)\n  if err != nil {\n    return nil, fmt.Errorf(\"protojson.Marshal: %s table: %w\", tableName, err)\n  }\n  return buf, nil\n}\n\nfunc runJitter(factor time.Duration) error {\n  randInt, err := rand.Int(rand.Reader, big.NewInt(factor.Milliseconds()))\n  if err != nil {\n    return fmt.Errorf(\"rand.Int: %w\", err)\n  }\n  randJitter := time.Duration(randInt.Int64()) * time.Millisecond\n  time.Sleep(randJitter)\n  return nil\n}\n\nvar tableTopics = map[string]string{\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName: \"{{.OutboxTopicName}}\",\n  {{- end}}\n}\n\nvar tableTypes = map[string]any{\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName: {{.OutboxProtoTyp}},\n  {{- end}}\n}\n\nconst (\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName = \"{{.OutboxTableName}}\"\n  {{- end}}\n)\n\nvar tableNames = []string{\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName,\n  {{- end}}\n}\n\n"
+  KafkaOutbox = "// Code generated by Boiler; DO NOT EDIT.\npackage kafkaoutbox\n\nimport (\n  {{- range .OutboxPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\ntype Outbox struct {\n  config   Config\n  storage  Storage\n  producer Producer\n}\n\ntype Producer interface {\n  SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error)\n}\n\ntype Storage interface {\n  LockRecords(ctx context.Context, tableName string) ([]*Record, error)\n  DeleteRecord(ctx context.Context, tableName, recordID string) error\n}\n\nfunc New(config Config, storage Storage, producer Producer) *Outbox {\n  return &Outbox{\n    config:   config,\n    storage:  storage,\n    producer: producer,\n  }\n}\n\nfunc (o *Outbox) Run(ctx context.Context) error {\n  if err := o.Validate(); err != nil {\n    return fmt.Errorf(\"outbox validation failed: %w\", err)\n  }\n  for _, tableName := range tableNames {\n    runJitter(o.config.JitterFactor)\n    o.sendRecordsWithWorkers(ctx, tableName)\n\n    log.Infof(\"kafka-outbox: sending started for: %s table\", tableName)\n  }\n  log.Infof(\"kafka-outbox: sending in progress\")\n\n  return nil\n}\n\nfunc (o *Outbox) Validate() error {\n  return validation.ValidateStruct(o,\n    validation.Field(&o.config),\n    validation.Field(&o.storage, validation.Required),\n    validation.Field(&o.producer, validation.Required),\n  )\n}\n\nfunc (o *Outbox) sendRecordsWithWorkers(ctx context.Context, tableName string) {\n  for worker := 0; worker < int(o.config.WorkersCount); worker++ {\n    runJitter(o.config.JitterFactor)\n    go func() { o.sendRecordsWithIdle(ctx, tableName) }()\n  }\n}\n\nfunc (o *Outbox) sendRecordsWithIdle(ctx context.Context, tableName string) {\n  ticker := time.NewTicker(o.config.WorkerIdle)\n  for {\n    select {\n    case <-ticker.C:\n      if err := o.sendRecords(ctx, tableName); err != nil {\n        log.Errorf(\"outbox.send: table: %s: error: %v\", tableName, err)\n      }\n    case <-ctx.Done():\n      log.Errorf(\"outbox.send: table: %s: context cancelled\", tableName)\n      return\n    }\n  }\n}\n\nfunc (o *Outbox) sendRecords(ctx context.Context, tableName string) error {\n  records, err := o.storage.LockRecords(ctx, tableName)\n  if err != nil {\n    return fmt.Errorf(\"storage.BatchRecords: %w\", err)\n  }\n  var msgBuf []byte\n\n  for _, record := range records {\n    msgBuf, err = marshalRecord(tableName, record)\n    if err != nil {\n      return fmt.Errorf(\"marshalRecord: %w\", err)\n    }\n    topicName, ok := tableTopics[tableName]\n    if !ok {\n      return fmt.Errorf(\"topic not found: %s table\", tableName)\n    }\n    msgKey := record.ID\n\n    // Source record key built from the primary key columns\n    if record.RecordKey != nil {\n      msgKey = *record.RecordKey\n    }\n\n    msgHeaders := []sarama.RecordHeader{\n      {\n        Key:   []byte(\"action_typ\"),\n        Value: []byte(record.ActionTyp.String()),\n      },\n    }\n    _, _, err = o.producer.SendMessage(&sarama.ProducerMessage{\n      Topic:     topicName,\n      Key:       sarama.StringEncoder(msgKey),\n      Value:     sarama.StringEncoder(msgBuf),\n      Headers:   msgHeaders,\n      Timestamp: time.Now().UTC(),\n    })\n    if err != nil {\n      return fmt.Errorf(\"producer.SendMessage: %w\", err)\n    }\n    if err = o.storage.DeleteRecord(ctx, tableName, record.ID); err != nil {\n      return fmt.Errorf(\"storage.DeleteRecord: %w\", err)\n    }\n  }\n  return nil\n}\n\nfunc marshalRecord(tableName string, record *Record) ([]byte, error) {\n  typ, ok := tableTypes[tableName]\n  if !ok {\n    return nil, fmt.Errorf(\"type not found: %s table\", tableName)\n  }\n  refTyp := reflect.TypeOf(typ)\n  pb := reflect.New(refTyp).Interface().(protoreflect.ProtoMessage)\n\n  pbOpts := protojson.UnmarshalOptions{\n    AllowPartial:   true,\n    DiscardUnknown: true,\n  }\n  if err := pbOpts.Unmarshal(record.JSONOut, pb); err != nil {\n    return nil, fmt.Errorf(\"protojson.Unmarshal: %s table: %w\", tableName, err)\n  }\n  buf, err := protojson.Marshal(pb)\n  if err != nil {\n    return nil, fmt.Errorf(\"protojson.Marshal: %s table: %w\", tableName, err)\n  }\n  return buf, nil\n}\n\nfunc runJitter(factor time.Duration) error {\n  randInt, err := rand.Int(rand.Reader, big.NewInt(factor.Milliseconds()))\n  if err != nil {\n    return fmt.Errorf(\"rand.Int: %w\", err)\n  }\n  randJitter := time.Duration(randInt.Int64()) * time.Millisecond\n  time.Sleep(randJitter)\n  return nil\n}\n\nvar tableTopics = map[string]string{\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName: \"{{.OutboxTopicName}}\",\n  {{- end}}\n}\n\nvar tableTypes = map[string]any{\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName: {{.OutboxProtoTyp}},\n  {{- end}}\n}\n\nconst (\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName = \"{{.OutboxTableName}}\"\n  {{- end}}\n)\n\nvar tableNames = []string{\n  {{- range .OutboxTables}}\n  {{toLowerCamelCase .OutboxTableName}}TableName,\n  {{- end}}\n}\n"
   // KafkaOutboxModels ...
-  KafkaOutboxModels = "// Code generated by Boiler; DO NOT EDIT.\npackage kafkaoutbox\n\nimport (\n  {{- range .OutboxModelsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\nconst (\n  CreateActionTyp ActionTyp = 1\n  UpdateActionTyp ActionTyp = 2\n  DeleteActionTyp ActionTyp = 3\n)\n\nvar actionTypString = map[ActionTyp]string{\n  CreateActionTyp: \"create\",\n  UpdateActionTyp: \"update\",\n  DeleteActionTyp: \"delete\",\n}\n\ntype ActionTyp int32\n\ntype Record struct {\n  ID          string     `db:\"id\"`\n  ActionTyp   ActionTyp  `db:\"action_typ\"`\n  JSONOut     []byte     `db:\"json_out\"`\n  LockedUntil *time.Time `db:\"locked_until\"`\n  CreatedAt   time.Time  `db:\"created_at\"`\n}\n\nfunc (r *Record) IsLocked() bool {\n  return r.LockedUntil != nil && time.Now().UTC().Before(*r.LockedUntil)\n}\n\nfunc (t ActionTyp) String() string {\n  actionTyp, ok := actionTypString[t]\n  if !ok {\n    return \"unknown\"\n  }\n  return actionTyp\n}\n"
+  KafkaOutboxModels = "// Code generated by Boiler; DO NOT EDIT.\npackage kafkaoutbox\n\nimport (\n  {{- range .OutboxModelsPackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\nconst (\n  CreateActionTyp ActionTyp = 1\n  UpdateActionTyp ActionTyp = 2\n  DeleteActionTyp ActionTyp = 3\n)\n\nvar actionTypString = map[ActionTyp]string{\n  CreateActionTyp: \"create\",\n  UpdateActionTyp: \"update\",\n  DeleteActionTyp: \"delete\",\n}\n\ntype ActionTyp int32\n\ntype Record struct {\n  ID          string     `db:\"id\"`\n  ActionTyp   ActionTyp  `db:\"action_typ\"`\n  JSONOut     []byte     `db:\"json_out\"`\n  RecordKey   *string    `db:\"record_key\"`\n  LockedUntil *time.Time `db:\"locked_until\"`\n  CreatedAt   time.Time  `db:\"created_at\"`\n}\n\nfunc (r *Record) IsLocked() bool {\n  return r.LockedUntil != nil && time.Now().UTC().Before(*r.LockedUntil)\n}\n\nfunc (t ActionTyp) String() string {\n  actionTyp, ok := actionTypString[t]\n  if !ok {\n    return \"unknown\"\n  }\n  return actionTyp\n}\n"
   // KafkaOutboxStorage ...
   KafkaOutboxStorage = "// Code generated by Boiler; DO NOT EDIT.\npackage kafkaoutbox\n\nimport (\n  {{- range .OutboxStoragePackages}}\n  {{.ImportAlias}} \"{{.ImportLine}}\"\n  {{- end}}\n)\n\ntype storage struct {\n  executor  pg.Executor\n  lockTTL   time.Duration\n  batchSize uint32\n}\n\nfunc NewStorage(executor pg.Executor, lockTTL time.Duration, batchSize uint32) Storage {\n  return &storage{\n    executor:  executor,\n    lockTTL:   lockTTL,\n    batchSize: batchSize,\n  }\n}\n\nfunc (s *storage) LockRecords(ctx context.Context, tableName string) ([]*Record, error) {\n  var locked []*Record\n\n  err := s.withTx(ctx, func(s *storage) error {\n    records, err := s.getRecordsBatch(ctx, tableName)\n    if err != nil {\n      return fmt.Errorf(\"getRecordsBatch: %w\", err)\n    }\n    unlocked := make([]*Record, 0, len(records))\n\n    for _, record := range records {\n      if record.IsLocked() {\n        continue\n      }\n      unlocked = append(unlocked, record)\n    }\n\n    locked, err = s.lockRecordsBatch(ctx, tableName, unlocked)\n    if err != nil {\n      return fmt.Errorf(\"lockRecordsBatch: %w\", err)\n    }\n    return nil\n  })\n\n  if err != nil {\n    return nil, fmt.Errorf(\"withTx: %w\", err)\n  }\n  return locked, nil\n}\n\nfunc (s *storage) getRecordsBatch(ctx context.Context, tableName string) ([]*Record, error) {\n  query := `select * from %s \n        where locked_until is null or locked_until < now() \n        order by created_at desc limit %d \n        for update`\n\n  query = fmt.Sprintf(query, tableName, s.batchSize)\n\n  records, err := pg.SelectCtx[*Record](ctx, s.executor, sq.Expr(query))\n  if err != nil {\n    return nil, fmt.Errorf(\"pg.SelectCtx: %w\", err)\n  }\n  return records, nil\n}\n\nfunc (s *storage) lockRecordsBatch(ctx context.Context, tableName string, records []*Record) ([]*Record, error) {\n  if len(records) == 0 {\n    return nil, nil\n  }\n  recordIDs := make([]string, 0, len(records))\n\n  for _, record := range records {\n    recordIDs = append(recordIDs, quote.String(record.ID))\n  }\n  query := `update %s set locked_until = now() + interval '%d millisecond' where id in (%s) returning *`\n\n  query = fmt.Sprintf(query, tableName, s.lockTTL.Milliseconds(), strings.Join(recordIDs, \",\"))\n\n  locked, err := pg.SelectCtx[*Record](ctx, s.executor, sq.Expr(query))\n  if err != nil {\n    return nil, fmt.Errorf(\"pg.SelectCtx: %w\", err)\n  }\n  return locked, nil\n}\n\nfunc (s *storage) withTx(ctx context.Context, fTx func(*storage) error) error {\n  defer func() {\n    if rec := recover(); rec != nil {\n      log.Errorf(\"storage.WithTransaction: panic recovered: %v\", rec)\n    }\n  }()\n\n  tx, err := s.executor.Begin(ctx)\n  if err != nil {\n    return fmt.Errorf(\"s.executor.BeginTx: %w\", err)\n  }\n  txStorage := &storage{\n    executor:  tx,\n    lockTTL:   s.lockTTL,\n    batchSize: s.batchSize,\n  }\n\n  if err = fTx(txStorage); err != nil {\n    if errRollback := tx.Rollback(ctx); errRollback != nil {\n      log.Errorf(\"storage.WithTransaction: tx.Rollback: %v\", errRollback)\n    }\n    return err\n  }\n\n  if err = tx.Commit(ctx); err != nil {\n    return fmt.Errorf(\"tx.Commit: %w\", err)\n  }\n  return nil\n}\n\nfunc (s *storage) DeleteRecord(ctx context.Context, tableName, recordID string) error {\n  query := fmt.Sprintf(`delete from %s where id = '%s'`, tableName, recordID)\n  return pg.ExecCtx(ctx, s.executor, sq.Expr(query))\n}\n"
   // KafkaOutboxConfig ...
@@ -215,11 +249,13 @@ const (
   // KafkaOutboxMigrationUUIDOssp ...
   KafkaOutboxMigrationUUIDOssp = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate extension if not exists \"uuid-ossp\";\n\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ndrop extension \"uuid-ossp\";\n\n-- +goose StatementEnd"
   // KafkaOutboxMigrationOutboxTable ...
-  KafkaOutboxMigrationOutboxTable = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate table if not exists {{.OutboxTableName}} (\n    id uuid not null primary key default uuid_generate_v4(),\n    action_typ int not null,\n    json_out json not null,\n    locked_until timestamp without time zone,\n    created_at timestamp without time zone not null default now()\n);\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ndrop table {{.OutboxTableName}};\n\n-- +goose StatementEnd\n"
+  KafkaOutboxMigrationOutboxTable = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate table if not exists {{.OutboxTableName}} (\n    id uuid not null primary key default uuid_generate_v4(),\n    action_typ int not null,\n    json_out json not null,\n    record_key text,\n    locked_until timestamp without time zone,\n    created_at timestamp without time zone not null default now()\n);\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ndrop table {{.OutboxTableName}};\n\n-- +goose StatementEnd\n"
   // KafkaOutboxMigrationOutboxFunc ...
-  KafkaOutboxMigrationOutboxFunc = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate or replace function {{.OutboxFuncName}}() returns trigger as ${{.OutboxFuncName}}$\nbegin\n    if (lower(tg_op::text) = 'delete') then\n        insert into {{.OutboxTableName}}(action_typ, json_out) values (3, row_to_json(old));\n\n    elseif (lower(tg_op::text) = 'update') then\n        insert into {{.OutboxTableName}}(action_typ, json_out) values (2, row_to_json(new));\n\n    elseif (lower(tg_op::text) = 'insert') then\n        insert into {{.OutboxTableName}}(action_typ, json_out) values (1, row_to_json(new));\n    end if;\n\n    return null;\nend;\n${{.OutboxFuncName}}$ language plpgsql;\n\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ndrop function {{.OutboxFuncName}};\n\n-- +goose StatementEnd\n"
+  KafkaOutboxMigrationOutboxFunc = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate or replace function {{.OutboxFuncName}}() returns trigger as ${{.OutboxFuncName}}$\nbegin\n    if (lower(tg_op::text) = 'delete') then\n        insert into {{.OutboxTableName}}(action_typ, json_out, record_key) values (3, row_to_json(old), {{.OutboxOldRecordKey}});\n\n    elseif (lower(tg_op::text) = 'update') then\n        insert into {{.OutboxTableName}}(action_typ, json_out, record_key) values (2, row_to_json(new), {{.OutboxNewRecordKey}});\n\n    elseif (lower(tg_op::text) = 'insert') then\n        insert into {{.OutboxTableName}}(action_typ, json_out, record_key) values (1, row_to_json(new), {{.OutboxNewRecordKey}});\n    end if;\n\n    return null;\nend;\n${{.OutboxFuncName}}$ language plpgsql;\n\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ndrop function {{.OutboxFuncName}};\n\n-- +goose StatementEnd\n"
   // KafkaOutboxMigrationOutboxTrigger ...
   KafkaOutboxMigrationOutboxTrigger = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate or replace trigger {{.OutboxTriggerName}}\n    after insert or update or delete on {{.SourceTableName}}\n    for each row execute procedure {{.OutboxFuncName}}();\n\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ndrop function {{.OutboxFuncName}};\n\n-- +goose StatementEnd\n"
+  // KafkaOutboxMigrationOutboxRecordKey ...
+  KafkaOutboxMigrationOutboxRecordKey = "-- +goose Up\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\nalter table {{.OutboxTableName}} add column if not exists record_key text;\n-- +goose StatementEnd\n\n-- +goose StatementBegin\ncreate or replace function {{.OutboxFuncName}}() returns trigger as ${{.OutboxFuncName}}$\nbegin\n    if (lower(tg_op::text) = 'delete') then\n        insert into {{.OutboxTableName}}(action_typ, json_out, record_key) values (3, row_to_json(old), {{.OutboxOldRecordKey}});\n\n    elseif (lower(tg_op::text) = 'update') then\n        insert into {{.OutboxTableName}}(action_typ, json_out, record_key) values (2, row_to_json(new), {{.OutboxNewRecordKey}});\n\n    elseif (lower(tg_op::text) = 'insert') then\n        insert into {{.OutboxTableName}}(action_typ, json_out, record_key) values (1, row_to_json(new), {{.OutboxNewRecordKey}});\n    end if;\n\n    return null;\nend;\n${{.OutboxFuncName}}$ language plpgsql;\n\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- migration generated by Boiler; DO NOT EDIT.\ncreate or replace function {{.OutboxFuncName}}() returns trigger as ${{.OutboxFuncName}}$\nbegin\n    if (lower(tg_op::text) = 'delete') then\n        insert into {{.OutboxTableName}}(action_typ, json_out) values (3, row_to_json(old));\n\n    elseif (lower(tg_op::text) = 'update') then\n        insert into {{.OutboxTableName}}(action_typ, json_out) values (2, row_to_json(new));\n\n    elseif (lower(tg_op::text) = 'insert') then\n        insert into {{.OutboxTableName}}(action_typ, json_out) values (1, row_to_json(new));\n    end if;\n\n    return null;\nend;\n${{.OutboxFuncName}}$ language plpgsql;\n-- +goose StatementEnd\n\n-- +goose StatementBegin\nalter table {{.OutboxTableName}} drop column if exists record_key;\n\n-- +goose StatementEnd\n"
 
   // KafkaOutboxConfigYaml ...
   KafkaOutboxConfigYaml = "# Config generated by Boiler; YOU MUST CHANGE THIS.\n\n# Kafka outbox config\nkafka_outbox:\n  # Kafka brokers\n  kafka_brokers_addr:\n    - \"localhost:9092\"\n\n  # Outbox records\n  record_lock_time: \"1s\"\n  records_batch_size: 100\n\n  # Outbox workers\n  worker_idle: \"100ms\"\n  workers_count: 5\n\n  # Jitter factor\n  jitter_factor: \"100ms\"\n"